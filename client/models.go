@@ -0,0 +1,263 @@
+package client
+
+import (
+	"github.com/vmware/go-vmware-nsxt/common"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+// DatapathCPUStats represents datapath CPU usage of an edge transport node.
+type DatapathCPUStats struct {
+	Cores []DatapathCPUCoreStats `json:"cores,omitempty"`
+}
+
+// DatapathCPUCoreStats represents datapath CPU usage of a single core.
+type DatapathCPUCoreStats struct {
+	Core  int64   `json:"core"`
+	Usage float64 `json:"usage"`
+}
+
+// EdgeClusterAllocationStatus represents service allocation of edge cluster members.
+type EdgeClusterAllocationStatus struct {
+	ID          string                              `json:"id,omitempty"`
+	DisplayName string                              `json:"display_name,omitempty"`
+	MemberCount int64                               `json:"member_count,omitempty"`
+	Members     []EdgeClusterMemberAllocationStatus `json:"members,omitempty"`
+}
+
+// EdgeClusterMemberAllocationStatus represents services allocated on a single edge cluster member.
+type EdgeClusterMemberAllocationStatus struct {
+	MemberIndex       int64                             `json:"member_index"`
+	TransportNodeID   string                            `json:"transport_node_id,omitempty"`
+	NodeDisplayName   string                            `json:"node_display_name,omitempty"`
+	AllocationPools   []EdgeClusterMemberAllocationPool `json:"allocation_pools,omitempty"`
+	AllocatedServices []AllocatedService                `json:"allocated_services,omitempty"`
+}
+
+// EdgeClusterMemberAllocationPool represents capacity usage of an allocation pool on an edge cluster member.
+type EdgeClusterMemberAllocationPool struct {
+	AllocationPoolType string  `json:"allocation_pool_type,omitempty"`
+	UsagePercentage    float64 `json:"usage_percentage,omitempty"`
+}
+
+// AllocatedService represents a service placed on an edge cluster member.
+type AllocatedService struct {
+	HighAvailabilityStatus string                   `json:"high_availability_status,omitempty"`
+	ServiceReference       common.ResourceReference `json:"service_reference"`
+}
+
+// AlarmListResult represents a page of alarms raised by the NSX 3.x alarm framework.
+type AlarmListResult struct {
+	Cursor  string  `json:"cursor,omitempty"`
+	Results []Alarm `json:"results,omitempty"`
+}
+
+// Alarm represents an alarm raised by the NSX 3.x alarm framework.
+type Alarm struct {
+	ID                 string `json:"id,omitempty"`
+	FeatureName        string `json:"feature_name,omitempty"`
+	EventType          string `json:"event_type,omitempty"`
+	Severity           string `json:"severity,omitempty"`
+	Status             string `json:"status,omitempty"`
+	EntityID           string `json:"entity_id,omitempty"`
+	EntityResourceType string `json:"entity_resource_type,omitempty"`
+	NodeID             string `json:"node_id,omitempty"`
+	LastReportedTime   int64  `json:"last_reported_time,omitempty"`
+}
+
+// IPSecVPNSessionListResult represents a page of IPSec VPN sessions.
+type IPSecVPNSessionListResult struct {
+	Cursor  string            `json:"cursor,omitempty"`
+	Results []IPSecVPNSession `json:"results,omitempty"`
+}
+
+// IPSecVPNSession represents an IPSec VPN session between local and peer endpoint.
+type IPSecVPNSession struct {
+	ID                string `json:"id,omitempty"`
+	DisplayName       string `json:"display_name,omitempty"`
+	ResourceType      string `json:"resource_type,omitempty"`
+	Enabled           bool   `json:"enabled"`
+	IPSecVPNServiceID string `json:"ipsec_vpn_service_id,omitempty"`
+	LocalEndpointID   string `json:"local_endpoint_id,omitempty"`
+	PeerEndpointID    string `json:"peer_endpoint_id,omitempty"`
+}
+
+// IPSecVPNLocalEndpointListResult represents a page of IPSec VPN local endpoints.
+type IPSecVPNLocalEndpointListResult struct {
+	Cursor  string                  `json:"cursor,omitempty"`
+	Results []IPSecVPNLocalEndpoint `json:"results,omitempty"`
+}
+
+// IPSecVPNLocalEndpoint represents the local termination of IPSec VPN sessions.
+type IPSecVPNLocalEndpoint struct {
+	ID           string `json:"id,omitempty"`
+	DisplayName  string `json:"display_name,omitempty"`
+	LocalAddress string `json:"local_address,omitempty"`
+}
+
+// IPSecVPNPeerEndpointListResult represents a page of IPSec VPN peer endpoints.
+type IPSecVPNPeerEndpointListResult struct {
+	Cursor  string                 `json:"cursor,omitempty"`
+	Results []IPSecVPNPeerEndpoint `json:"results,omitempty"`
+}
+
+// IPSecVPNPeerEndpoint represents the remote termination of IPSec VPN sessions.
+type IPSecVPNPeerEndpoint struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	PeerAddress string `json:"peer_address,omitempty"`
+	PeerID      string `json:"peer_id,omitempty"`
+}
+
+// IPSecVPNSessionStatus represents the aggregated status of an IPSec VPN session.
+type IPSecVPNSessionStatus struct {
+	IPSecVPNSessionID string                    `json:"ipsec_vpn_session_id,omitempty"`
+	SessionStatus     string                    `json:"session_status,omitempty"`
+	FailedTunnels     int64                     `json:"failed_tunnels,omitempty"`
+	NegotiatedTunnels int64                     `json:"negotiated_tunnels,omitempty"`
+	TotalTunnels      int64                     `json:"total_tunnels,omitempty"`
+	IkeStatus         *IPSecVPNIKESessionStatus `json:"ike_status,omitempty"`
+	TrafficCounters   *IPSecVPNTrafficCounters  `json:"aggregate_traffic_counters,omitempty"`
+}
+
+// IPSecVPNIKESessionStatus represents the status of IKE negotiation of an IPSec VPN session.
+type IPSecVPNIKESessionStatus struct {
+	IkeSessionState string `json:"ike_session_state,omitempty"`
+	FailReason      string `json:"fail_reason,omitempty"`
+}
+
+// IPSecVPNTrafficCounters represents traffic counters of an IPSec VPN session.
+type IPSecVPNTrafficCounters struct {
+	BytesIn           int64 `json:"bytes_in,omitempty"`
+	BytesOut          int64 `json:"bytes_out,omitempty"`
+	PacketsIn         int64 `json:"packets_in,omitempty"`
+	PacketsOut        int64 `json:"packets_out,omitempty"`
+	DroppedPacketsIn  int64 `json:"dropped_packets_in,omitempty"`
+	DroppedPacketsOut int64 `json:"dropped_packets_out,omitempty"`
+}
+
+// IPSecVPNSessionStatistics represents per policy and per tunnel statistics of an IPSec VPN session.
+type IPSecVPNSessionStatistics struct {
+	IPSecVPNSessionID string                            `json:"ipsec_vpn_session_id,omitempty"`
+	PolicyStatistics  []IPSecVPNPolicyTrafficStatistics `json:"policy_statistics,omitempty"`
+}
+
+// IPSecVPNPolicyTrafficStatistics represents statistics of tunnels negotiated for an IPSec VPN policy rule.
+type IPSecVPNPolicyTrafficStatistics struct {
+	PolicyID         string                            `json:"policy_id,omitempty"`
+	TunnelStatistics []IPSecVPNTunnelTrafficStatistics `json:"tunnel_statistics,omitempty"`
+}
+
+// IPSecVPNTunnelTrafficStatistics represents status and traffic counters of a single IPSec VPN tunnel.
+type IPSecVPNTunnelTrafficStatistics struct {
+	LocalSubnet       string `json:"local_subnet,omitempty"`
+	PeerSubnet        string `json:"peer_subnet,omitempty"`
+	TunnelStatus      string `json:"tunnel_status,omitempty"`
+	TunnelDownReason  string `json:"tunnel_down_reason,omitempty"`
+	BytesIn           int64  `json:"bytes_in,omitempty"`
+	BytesOut          int64  `json:"bytes_out,omitempty"`
+	PacketsIn         int64  `json:"packets_in,omitempty"`
+	PacketsOut        int64  `json:"packets_out,omitempty"`
+	DroppedPacketsIn  int64  `json:"dropped_packets_in,omitempty"`
+	DroppedPacketsOut int64  `json:"dropped_packets_out,omitempty"`
+}
+
+// L2VPNSessionListResult represents a page of L2VPN sessions.
+type L2VPNSessionListResult struct {
+	Cursor  string         `json:"cursor,omitempty"`
+	Results []L2VPNSession `json:"results,omitempty"`
+}
+
+// L2VPNSession represents an L2VPN session stretching logical switches to a peer site.
+type L2VPNSession struct {
+	ID             string `json:"id,omitempty"`
+	DisplayName    string `json:"display_name,omitempty"`
+	Enabled        bool   `json:"enabled"`
+	L2VPNServiceID string `json:"l2vpn_service_id,omitempty"`
+}
+
+// L2VPNSessionStatus represents the status of an L2VPN session and its transport tunnels.
+type L2VPNSessionStatus struct {
+	SessionID        string              `json:"session_id,omitempty"`
+	Status           string              `json:"status,omitempty"`
+	TransportTunnels []L2VPNTunnelStatus `json:"transport_tunnels,omitempty"`
+}
+
+// L2VPNTunnelStatus represents the status of a transport tunnel of an L2VPN session.
+type L2VPNTunnelStatus struct {
+	TunnelID common.ResourceReference `json:"tunnel_id"`
+	Status   string                   `json:"status,omitempty"`
+}
+
+// L2VPNSessionStatistics represents traffic statistics of an L2VPN session.
+type L2VPNSessionStatistics struct {
+	SessionID                         string                                `json:"session_id,omitempty"`
+	TrafficStatisticsPerLogicalSwitch []L2VPNLogicalSwitchTrafficStatistics `json:"traffic_statistics_per_logical_switch,omitempty"`
+}
+
+// L2VPNLogicalSwitchTrafficStatistics represents traffic counters of a logical switch stretched over L2VPN.
+type L2VPNLogicalSwitchTrafficStatistics struct {
+	LogicalSwitch common.ResourceReference `json:"logical_switch"`
+	BytesIn       int64                    `json:"bytes_in,omitempty"`
+	BytesOut      int64                    `json:"bytes_out,omitempty"`
+	PacketsIn     int64                    `json:"packets_in,omitempty"`
+	PacketsOut    int64                    `json:"packets_out,omitempty"`
+	BumPacketsIn  int64                    `json:"bum_packets_in,omitempty"`
+	BumPacketsOut int64                    `json:"bum_packets_out,omitempty"`
+}
+
+// LoadBalancerServiceUsage represents capacity and usage of a load balancer service.
+type LoadBalancerServiceUsage struct {
+	ServiceID                 string  `json:"service_id,omitempty"`
+	ServiceSize               string  `json:"service_size,omitempty"`
+	UsagePercentage           float64 `json:"usage_percentage,omitempty"`
+	Severity                  string  `json:"severity,omitempty"`
+	CurrentVirtualServerCount int64   `json:"current_virtual_server_count,omitempty"`
+	VirtualServerCapacity     int64   `json:"virtual_server_capacity,omitempty"`
+	CurrentPoolCount          int64   `json:"current_pool_count,omitempty"`
+	PoolCapacity              int64   `json:"pool_capacity,omitempty"`
+	CurrentPoolMemberCount    int64   `json:"current_pool_member_count,omitempty"`
+	PoolMemberCapacity        int64   `json:"pool_member_capacity,omitempty"`
+}
+
+// LoadBalancerNodeUsage represents load balancer credit consumption of an edge node.
+type LoadBalancerNodeUsage struct {
+	NodeID              string  `json:"node_id,omitempty"`
+	FormFactor          string  `json:"form_factor,omitempty"`
+	UsagePercentage     float64 `json:"usage_percentage,omitempty"`
+	Severity            string  `json:"severity,omitempty"`
+	CurrentCreditNumber int64   `json:"current_credit_number,omitempty"`
+	CreditCapacity      int64   `json:"credit_capacity,omitempty"`
+}
+
+// LoadBalancerMonitorListResult represents a page of load balancer health monitors.
+type LoadBalancerMonitorListResult struct {
+	Cursor  string                `json:"cursor,omitempty"`
+	Results []LoadBalancerMonitor `json:"results,omitempty"`
+}
+
+// LoadBalancerMonitor represents an active or passive health monitor of load balancer pools.
+type LoadBalancerMonitor struct {
+	ID           string `json:"id,omitempty"`
+	DisplayName  string `json:"display_name,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	MonitorPort  string `json:"monitor_port,omitempty"`
+}
+
+// LogicalPortOperationalStatusListResult represents a page of logical port operational status.
+type LogicalPortOperationalStatusListResult struct {
+	Cursor  string                                 `json:"cursor,omitempty"`
+	Results []manager.LogicalPortOperationalStatus `json:"results,omitempty"`
+}
+
+// FirewallStats represents statistics of a distributed firewall rule.
+// Hit count, session and popularity fields are only reported by NSX-T 2.4 and later.
+type FirewallStats struct {
+	RuleID            string `json:"rule_id,omitempty"`
+	PacketCount       int64  `json:"packet_count,omitempty"`
+	ByteCount         int64  `json:"byte_count,omitempty"`
+	SessionCount      int64  `json:"session_count,omitempty"`
+	HitCount          int64  `json:"hit_count,omitempty"`
+	MaxSessionCount   int64  `json:"max_session_count,omitempty"`
+	TotalSessionCount int64  `json:"total_session_count,omitempty"`
+	PopularityIndex   int64  `json:"popularity_index,omitempty"`
+}
@@ -1,11 +1,21 @@
 package client
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
 	"github.com/go-kit/kit/log"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/administration"
+	"github.com/vmware/go-vmware-nsxt/apiservice"
+	"github.com/vmware/go-vmware-nsxt/licensing"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/go-vmware-nsxt/trust"
+	"github.com/vmware/go-vmware-nsxt/upgrade"
 )
 
 type nsxtClient struct {
@@ -20,6 +30,47 @@ func NewNSXTClient(apiClient *nsxt.APIClient, logger log.Logger) *nsxtClient {
 	}
 }
 
+// ErrNotFound is returned when NSX-T manager does not provide the requested API.
+var ErrNotFound = errors.New("not found")
+
+// ErrUpgradeCoordinatorNotRunning is returned when upgrade APIs are unavailable because the upgrade coordinator is stopped.
+var ErrUpgradeCoordinatorNotRunning = errors.New("upgrade coordinator is not running")
+
+// get reads an API resource which is not covered by the generated SDK.
+// The request is sent through the batch API so it reuses the session and TLS settings of the SDK client.
+func (c *nsxtClient) get(uri string, result interface{}) error {
+	batchRequest := apiservice.BatchRequest{
+		Requests: []apiservice.BatchRequestItem{
+			{
+				Method: "GET",
+				Uri:    uri,
+			},
+		},
+	}
+	batchResponse, _, err := c.apiClient.ApiServicesApi.RegisterBatchRequest(c.apiClient.Context, batchRequest, nil)
+	if err != nil {
+		return err
+	}
+	if len(batchResponse.Results) == 0 {
+		return fmt.Errorf("empty batch response for %s", uri)
+	}
+	res := batchResponse.Results[0]
+	if res.Code == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", uri, ErrNotFound)
+	}
+	if res.Code >= 300 {
+		return fmt.Errorf("unexpected status code %d for %s", res.Code, uri)
+	}
+	if res.Body == nil {
+		return nil
+	}
+	body, err := json.Marshal(*res.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
 func (c *nsxtClient) ListAllLogicalRouters() ([]manager.LogicalRouter, error) {
 	var logicalRouters []manager.LogicalRouter
 	var cursor string
@@ -75,11 +126,99 @@ func (c *nsxtClient) ListLogicalPorts(localVarOptionals map[string]interface{})
 	return lportsResult, err
 }
 
+func (c *nsxtClient) ListAllLogicalPorts() ([]manager.LogicalPort, error) {
+	var lports []manager.LogicalPort
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		lportsResult, _, err := c.apiClient.LogicalSwitchingApi.ListLogicalPorts(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		lports = append(lports, lportsResult.Results...)
+		cursor = lportsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return lports, nil
+}
+
 func (c *nsxtClient) GetLogicalPortOperationalStatus(lportId string, localVarOptionals map[string]interface{}) (manager.LogicalPortOperationalStatus, error) {
 	lportStatus, _, err := c.apiClient.LogicalSwitchingApi.GetLogicalPortOperationalStatus(c.apiClient.Context, lportId, localVarOptionals)
 	return lportStatus, err
 }
 
+// ListAllLogicalPortOperationalStatuses lists operational status of all logical ports in bulk.
+// The endpoint is not part of the generated SDK, managers which do not serve it return ErrNotFound
+// so callers can fall back to GetLogicalPortOperationalStatus.
+func (c *nsxtClient) ListAllLogicalPortOperationalStatuses() ([]manager.LogicalPortOperationalStatus, error) {
+	var lportStatuses []manager.LogicalPortOperationalStatus
+	var cursor string
+	for {
+		var lportStatusesResult LogicalPortOperationalStatusListResult
+		err := c.get("/v1/logical-ports/operational-status?cursor="+url.QueryEscape(cursor), &lportStatusesResult)
+		if err != nil {
+			return nil, err
+		}
+		lportStatuses = append(lportStatuses, lportStatusesResult.Results...)
+		cursor = lportStatusesResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return lportStatuses, nil
+}
+
+func (c *nsxtClient) GetLogicalPortStatistics(lportID string) (manager.LogicalPortStatistics, error) {
+	lportStatistics, _, err := c.apiClient.LogicalSwitchingApi.GetLogicalPortStatistics(c.apiClient.Context, lportID, nil)
+	return lportStatistics, err
+}
+
+func (c *nsxtClient) GetLogicalPortState(lportID string) (manager.LogicalPortState, error) {
+	lportState, _, err := c.apiClient.LogicalSwitchingApi.GetLogicalPortState(c.apiClient.Context, lportID)
+	return lportState, err
+}
+
+func (c *nsxtClient) ListAllVirtualMachines() ([]manager.VirtualMachine, error) {
+	var virtualMachines []manager.VirtualMachine
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		virtualMachinesResult, _, err := c.apiClient.FabricApi.ListVirtualMachines(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		virtualMachines = append(virtualMachines, virtualMachinesResult.Results...)
+		cursor = virtualMachinesResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return virtualMachines, nil
+}
+
+func (c *nsxtClient) ListAllVifs() ([]manager.VirtualNetworkInterface, error) {
+	var vifs []manager.VirtualNetworkInterface
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		vifsResult, _, err := c.apiClient.FabricApi.ListVifs(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		vifs = append(vifs, vifsResult.Results...)
+		cursor = vifsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return vifs, nil
+}
+
 func (c *nsxtClient) ListAllLogicalRouterPorts() ([]manager.LogicalRouterPort, error) {
 	var logicalRouterPorts []manager.LogicalRouterPort
 	var cursor string
@@ -133,6 +272,49 @@ func (c *nsxtClient) GetDHCPStatistic(dhcpID string) (manager.DhcpStatistics, er
 	return dhcpServerStatistic, err
 }
 
+func (c *nsxtClient) GetDHCPLeaseInfo(dhcpID string) (manager.DhcpLeases, error) {
+	dhcpLeases, _, err := c.apiClient.ServicesApi.GetDhcpLeaseInfo(c.apiClient.Context, dhcpID, nil)
+	return dhcpLeases, err
+}
+
+func (c *nsxtClient) ListAllDHCPIPPools(dhcpID string) ([]manager.DhcpIpPool, error) {
+	var dhcpIPPools []manager.DhcpIpPool
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		dhcpIPPoolsResult, _, err := c.apiClient.ServicesApi.ListDhcpIpPools(c.apiClient.Context, dhcpID, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		dhcpIPPools = append(dhcpIPPools, dhcpIPPoolsResult.Results...)
+		cursor = dhcpIPPoolsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return dhcpIPPools, nil
+}
+
+func (c *nsxtClient) ListAllDHCPStaticBindings(dhcpID string) ([]manager.DhcpStaticBinding, error) {
+	var dhcpStaticBindings []manager.DhcpStaticBinding
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		dhcpStaticBindingsResult, _, err := c.apiClient.ServicesApi.ListDhcpStaticBindings(c.apiClient.Context, dhcpID, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		dhcpStaticBindings = append(dhcpStaticBindings, dhcpStaticBindingsResult.Results...)
+		cursor = dhcpStaticBindingsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return dhcpStaticBindings, nil
+}
+
 func (c *nsxtClient) ListAllTransportNodes() ([]manager.TransportNode, error) {
 	var transportNodes []manager.TransportNode
 	var cursor string
@@ -275,6 +457,56 @@ func (c *nsxtClient) GetLogicalSwitchStatistic(lswitchID string) (manager.Logica
 	return logicalSwitchStatistic, err
 }
 
+// ListAllLogicalSwitchMacTableEntries returns the realtime MAC table of a logical switch.
+// The table is read from the central control plane when transportNodeID is empty.
+func (c *nsxtClient) ListAllLogicalSwitchMacTableEntries(lswitchID string, transportNodeID string) ([]manager.MacTableEntry, error) {
+	var macTableEntries []manager.MacTableEntry
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		localVarOptionals["source"] = "realtime"
+		if transportNodeID != "" {
+			localVarOptionals["transportNodeId"] = transportNodeID
+		}
+		macTableResult, _, err := c.apiClient.LogicalSwitchingApi.GetLogicalSwitchMacTable(c.apiClient.Context, lswitchID, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		macTableEntries = append(macTableEntries, macTableResult.Results...)
+		cursor = macTableResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return macTableEntries, nil
+}
+
+// ListAllLogicalSwitchVtepTableEntries returns the realtime VTEP table of a logical switch.
+// The table is read from the central control plane when transportNodeID is empty.
+func (c *nsxtClient) ListAllLogicalSwitchVtepTableEntries(lswitchID string, transportNodeID string) ([]manager.VtepTableEntry, error) {
+	var vtepTableEntries []manager.VtepTableEntry
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		localVarOptionals["source"] = "realtime"
+		if transportNodeID != "" {
+			localVarOptionals["transportNodeId"] = transportNodeID
+		}
+		vtepTableResult, _, err := c.apiClient.LogicalSwitchingApi.GetLogicalSwitchVtepTable(c.apiClient.Context, lswitchID, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		vtepTableEntries = append(vtepTableEntries, vtepTableResult.Results...)
+		cursor = vtepTableResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return vtepTableEntries, nil
+}
+
 func (c *nsxtClient) ListAllLoadBalancers() ([]loadbalancer.LbService, error) {
 	var loadBalancers []loadbalancer.LbService
 	var cursor string
@@ -294,6 +526,62 @@ func (c *nsxtClient) ListAllLoadBalancers() ([]loadbalancer.LbService, error) {
 	return loadBalancers, nil
 }
 
+func (c *nsxtClient) ListAllLoadBalancerVirtualServers() ([]loadbalancer.LbVirtualServer, error) {
+	var virtualServers []loadbalancer.LbVirtualServer
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		lbVirtualServerListResult, _, err := c.apiClient.ServicesApi.ListLoadBalancerVirtualServers(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		virtualServers = append(virtualServers, lbVirtualServerListResult.Results...)
+		cursor = lbVirtualServerListResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return virtualServers, nil
+}
+
+func (c *nsxtClient) ListAllLoadBalancerPools() ([]loadbalancer.LbPool, error) {
+	var pools []loadbalancer.LbPool
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		lbPoolListResult, _, err := c.apiClient.ServicesApi.ListLoadBalancerPools(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, lbPoolListResult.Results...)
+		cursor = lbPoolListResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return pools, nil
+}
+
+func (c *nsxtClient) ListAllLoadBalancerMonitors() ([]LoadBalancerMonitor, error) {
+	var monitors []LoadBalancerMonitor
+	var cursor string
+	for {
+		var monitorsResult LoadBalancerMonitorListResult
+		err := c.get("/v1/loadbalancer/monitors?cursor="+url.QueryEscape(cursor), &monitorsResult)
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, monitorsResult.Results...)
+		cursor = monitorsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return monitors, nil
+}
+
 func (c *nsxtClient) GetLoadBalancerStatus(loadBalancerID string) (loadbalancer.LbServiceStatus, error) {
 	loadBalancerStatus, _, err := c.apiClient.ServicesApi.ReadLoadBalancerServiceStatus(c.apiClient.Context, loadBalancerID, nil)
 	return loadBalancerStatus, err
@@ -344,7 +632,355 @@ func (c *nsxtClient) GetAllFirewallRules(sectionID string) ([]manager.FirewallRu
 	return firewallRules, nil
 }
 
-func (c *nsxtClient) GetFirewallStats(sectionID, ruleID string) (manager.FirewallStats, error) {
-	firewallStats, _, err := c.apiClient.ServicesApi.GetFirewallStats(c.apiClient.Context, sectionID, ruleID, nil)
+func (c *nsxtClient) GetFirewallStats(sectionID, ruleID string) (FirewallStats, error) {
+	var firewallStats FirewallStats
+	err := c.get(fmt.Sprintf("/v1/firewall/sections/%s/rules/%s/stats", sectionID, ruleID), &firewallStats)
 	return firewallStats, err
 }
+
+func (c *nsxtClient) GetTransportNodeSystemStatus(nodeID string) (manager.NodeStatus, error) {
+	localVarOptionals := make(map[string]interface{})
+	localVarOptionals["source"] = "realtime"
+	nodeStatus, _, err := c.apiClient.FabricApi.ReadNodeStatus(c.apiClient.Context, nodeID, localVarOptionals)
+	return nodeStatus, err
+}
+
+func (c *nsxtClient) GetEdgeDatapathCPUStats(transportNodeID string) (DatapathCPUStats, error) {
+	var cpuStats DatapathCPUStats
+	err := c.get(fmt.Sprintf("/v1/transport-nodes/%s/node/services/dataplane/cpu-stats", transportNodeID), &cpuStats)
+	return cpuStats, err
+}
+
+func (c *nsxtClient) ListTransportNodeInterfaces(nodeID string) ([]manager.NodeInterfaceProperties, error) {
+	interfacesResult, _, err := c.apiClient.FabricApi.ListFabricNodeInterfaces(c.apiClient.Context, nodeID, nil)
+	return interfacesResult.Results, err
+}
+
+func (c *nsxtClient) GetTransportNodeInterfaceStatistics(nodeID, interfaceID string) (manager.NodeInterfaceStatisticsProperties, error) {
+	interfaceStatistics, _, err := c.apiClient.FabricApi.ReadFabricNodeInterfaceStatistics(c.apiClient.Context, nodeID, interfaceID, nil)
+	return interfaceStatistics, err
+}
+
+func (c *nsxtClient) ListAllTransportZones() ([]manager.TransportZone, error) {
+	var transportZones []manager.TransportZone
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		transportZonesResult, _, err := c.apiClient.NetworkTransportApi.ListTransportZones(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		transportZones = append(transportZones, transportZonesResult.Results...)
+		cursor = transportZonesResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return transportZones, nil
+}
+
+func (c *nsxtClient) GetTransportZoneStatus(zoneID string) (manager.TransportZoneStatus, error) {
+	transportZoneStatus, _, err := c.apiClient.NetworkTransportApi.GetTransportZoneStatus(c.apiClient.Context, zoneID)
+	return transportZoneStatus, err
+}
+
+func (c *nsxtClient) GetEdgeClusterAllocationStatus(edgeClusterID string) (EdgeClusterAllocationStatus, error) {
+	var allocationStatus EdgeClusterAllocationStatus
+	err := c.get(fmt.Sprintf("/v1/edge-clusters/%s/allocation-status", edgeClusterID), &allocationStatus)
+	return allocationStatus, err
+}
+
+func (c *nsxtClient) ListAllComputeManagers() ([]manager.ComputeManager, error) {
+	var computeManagers []manager.ComputeManager
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		computeManagersResult, _, err := c.apiClient.FabricApi.ListComputeManagers(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		computeManagers = append(computeManagers, computeManagersResult.Results...)
+		cursor = computeManagersResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return computeManagers, nil
+}
+
+func (c *nsxtClient) GetComputeManagerStatus(computeManagerID string) (manager.ComputeManagerStatus, error) {
+	computeManagerStatus, _, err := c.apiClient.FabricApi.ReadComputeManagerStatus(c.apiClient.Context, computeManagerID)
+	return computeManagerStatus, err
+}
+
+func (c *nsxtClient) ListAllCertificates() ([]trust.Certificate, error) {
+	var certificates []trust.Certificate
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		certificatesResult, _, err := c.apiClient.NsxComponentAdministrationApi.GetCertificates(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificatesResult.Results...)
+		cursor = certificatesResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return certificates, nil
+}
+
+func (c *nsxtClient) ListAllLicenses() ([]licensing.License, error) {
+	licensesResult, _, err := c.apiClient.LicensingApi.GetLicenses(c.apiClient.Context)
+	return licensesResult.Results, err
+}
+
+func (c *nsxtClient) GetLicenseUsageReport() (licensing.FeatureUsageList, error) {
+	featureUsageList, _, err := c.apiClient.LicensingApi.GetLicenseUsageReport(c.apiClient.Context)
+	return featureUsageList, err
+}
+
+func (c *nsxtClient) GetBackupConfig() (administration.BackupConfiguration, error) {
+	backupConfig, _, err := c.apiClient.NsxComponentAdministrationApi.GetBackupConfig(c.apiClient.Context)
+	return backupConfig, err
+}
+
+func (c *nsxtClient) GetBackupHistory() (administration.BackupOperationHistory, error) {
+	backupHistory, _, err := c.apiClient.NsxComponentAdministrationApi.GetBackupHistory(c.apiClient.Context)
+	return backupHistory, err
+}
+
+func (c *nsxtClient) ReadNodeProperties() (manager.NodeProperties, error) {
+	nodeProperties, _, err := c.apiClient.NsxComponentAdministrationApi.ReadNodeProperties(c.apiClient.Context)
+	return nodeProperties, err
+}
+
+func (c *nsxtClient) ListAllAlarms() ([]Alarm, error) {
+	var alarms []Alarm
+	var cursor string
+	for {
+		var alarmsResult AlarmListResult
+		err := c.get("/v1/alarms?cursor="+url.QueryEscape(cursor), &alarmsResult)
+		if err != nil {
+			return nil, err
+		}
+		alarms = append(alarms, alarmsResult.Results...)
+		cursor = alarmsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return alarms, nil
+}
+
+func (c *nsxtClient) GetUpgradeSummary() (upgrade.UpgradeSummary, error) {
+	upgradeSummary, resp, err := c.apiClient.UpgradeApi.GetUpgradeSummary(c.apiClient.Context)
+	if err != nil && resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusServiceUnavailable) {
+		return upgradeSummary, fmt.Errorf("%v: %w", err, ErrUpgradeCoordinatorNotRunning)
+	}
+	return upgradeSummary, err
+}
+
+func (c *nsxtClient) GetUpgradeStatusSummary() (upgrade.UpgradeStatus, error) {
+	upgradeStatus, _, err := c.apiClient.UpgradeApi.GetUpgradeStatusSummary(c.apiClient.Context, nil)
+	return upgradeStatus, err
+}
+
+func (c *nsxtClient) ListAllUpgradeUnitGroupStatuses(componentType string) ([]upgrade.UpgradeUnitGroupStatus, error) {
+	var upgradeUnitGroupStatuses []upgrade.UpgradeUnitGroupStatus
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["componentType"] = componentType
+		localVarOptionals["cursor"] = cursor
+		upgradeUnitGroupStatusesResult, _, err := c.apiClient.UpgradeApi.GetUpgradeUnitGroupsStatus(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		upgradeUnitGroupStatuses = append(upgradeUnitGroupStatuses, upgradeUnitGroupStatusesResult.Results...)
+		cursor = upgradeUnitGroupStatusesResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return upgradeUnitGroupStatuses, nil
+}
+
+func (c *nsxtClient) GetUpgradeUnitsStats() ([]upgrade.UpgradeUnitTypeStats, error) {
+	upgradeUnitsStats, _, err := c.apiClient.UpgradeApi.GetUpgradeUnitsStats(c.apiClient.Context, nil)
+	return upgradeUnitsStats.Results, err
+}
+
+func (c *nsxtClient) ListAllIPPools() ([]manager.IpPool, error) {
+	var ipPools []manager.IpPool
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		ipPoolsResult, _, err := c.apiClient.PoolManagementApi.ListIpPools(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		ipPools = append(ipPools, ipPoolsResult.Results...)
+		cursor = ipPoolsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return ipPools, nil
+}
+
+func (c *nsxtClient) ListIPPoolAllocations(poolID string) ([]manager.AllocationIpAddress, error) {
+	allocationsResult, _, err := c.apiClient.PoolManagementApi.ListIpPoolAllocations(c.apiClient.Context, poolID)
+	return allocationsResult.Results, err
+}
+
+func (c *nsxtClient) ListAllIPBlocks() ([]manager.IpBlock, error) {
+	var ipBlocks []manager.IpBlock
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		ipBlocksResult, _, err := c.apiClient.PoolManagementApi.ListIpBlocks(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		ipBlocks = append(ipBlocks, ipBlocksResult.Results...)
+		cursor = ipBlocksResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return ipBlocks, nil
+}
+
+func (c *nsxtClient) ListAllIPBlockSubnets() ([]manager.IpBlockSubnet, error) {
+	var ipBlockSubnets []manager.IpBlockSubnet
+	var cursor string
+	for {
+		localVarOptionals := make(map[string]interface{})
+		localVarOptionals["cursor"] = cursor
+		ipBlockSubnetsResult, _, err := c.apiClient.PoolManagementApi.ListIpBlockSubnets(c.apiClient.Context, localVarOptionals)
+		if err != nil {
+			return nil, err
+		}
+		ipBlockSubnets = append(ipBlockSubnets, ipBlockSubnetsResult.Results...)
+		cursor = ipBlockSubnetsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return ipBlockSubnets, nil
+}
+
+func (c *nsxtClient) ListAllIPSecVPNSessions() ([]IPSecVPNSession, error) {
+	var sessions []IPSecVPNSession
+	var cursor string
+	for {
+		var sessionsResult IPSecVPNSessionListResult
+		err := c.get("/v1/vpn/ipsec/sessions?cursor="+url.QueryEscape(cursor), &sessionsResult)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sessionsResult.Results...)
+		cursor = sessionsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return sessions, nil
+}
+
+func (c *nsxtClient) ListAllIPSecVPNLocalEndpoints() ([]IPSecVPNLocalEndpoint, error) {
+	var localEndpoints []IPSecVPNLocalEndpoint
+	var cursor string
+	for {
+		var localEndpointsResult IPSecVPNLocalEndpointListResult
+		err := c.get("/v1/vpn/ipsec/local-endpoints?cursor="+url.QueryEscape(cursor), &localEndpointsResult)
+		if err != nil {
+			return nil, err
+		}
+		localEndpoints = append(localEndpoints, localEndpointsResult.Results...)
+		cursor = localEndpointsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return localEndpoints, nil
+}
+
+func (c *nsxtClient) ListAllIPSecVPNPeerEndpoints() ([]IPSecVPNPeerEndpoint, error) {
+	var peerEndpoints []IPSecVPNPeerEndpoint
+	var cursor string
+	for {
+		var peerEndpointsResult IPSecVPNPeerEndpointListResult
+		err := c.get("/v1/vpn/ipsec/peer-endpoints?cursor="+url.QueryEscape(cursor), &peerEndpointsResult)
+		if err != nil {
+			return nil, err
+		}
+		peerEndpoints = append(peerEndpoints, peerEndpointsResult.Results...)
+		cursor = peerEndpointsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return peerEndpoints, nil
+}
+
+func (c *nsxtClient) GetIPSecVPNSessionStatus(sessionID string) (IPSecVPNSessionStatus, error) {
+	var sessionStatus IPSecVPNSessionStatus
+	err := c.get(fmt.Sprintf("/v1/vpn/ipsec/sessions/%s/status", sessionID), &sessionStatus)
+	return sessionStatus, err
+}
+
+func (c *nsxtClient) GetIPSecVPNSessionStatistics(sessionID string) (IPSecVPNSessionStatistics, error) {
+	var sessionStatistics IPSecVPNSessionStatistics
+	err := c.get(fmt.Sprintf("/v1/vpn/ipsec/sessions/%s/statistics", sessionID), &sessionStatistics)
+	return sessionStatistics, err
+}
+
+func (c *nsxtClient) ListAllL2VPNSessions() ([]L2VPNSession, error) {
+	var sessions []L2VPNSession
+	var cursor string
+	for {
+		var sessionsResult L2VPNSessionListResult
+		err := c.get("/v1/vpn/l2vpn/sessions?cursor="+url.QueryEscape(cursor), &sessionsResult)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sessionsResult.Results...)
+		cursor = sessionsResult.Cursor
+		if len(cursor) == 0 {
+			break
+		}
+	}
+	return sessions, nil
+}
+
+func (c *nsxtClient) GetL2VPNSessionStatus(sessionID string) (L2VPNSessionStatus, error) {
+	var sessionStatus L2VPNSessionStatus
+	err := c.get(fmt.Sprintf("/v1/vpn/l2vpn/sessions/%s/status", sessionID), &sessionStatus)
+	return sessionStatus, err
+}
+
+func (c *nsxtClient) GetL2VPNSessionStatistics(sessionID string) (L2VPNSessionStatistics, error) {
+	var sessionStatistics L2VPNSessionStatistics
+	err := c.get(fmt.Sprintf("/v1/vpn/l2vpn/sessions/%s/statistics", sessionID), &sessionStatistics)
+	return sessionStatistics, err
+}
+
+func (c *nsxtClient) GetLoadBalancerServiceUsage(loadBalancerID string) (LoadBalancerServiceUsage, error) {
+	var serviceUsage LoadBalancerServiceUsage
+	err := c.get(fmt.Sprintf("/v1/loadbalancer/services/%s/usage", loadBalancerID), &serviceUsage)
+	return serviceUsage, err
+}
+
+func (c *nsxtClient) GetLoadBalancerNodeUsage(nodeID string) (LoadBalancerNodeUsage, error) {
+	var nodeUsage LoadBalancerNodeUsage
+	err := c.get(fmt.Sprintf("/v1/loadbalancer/usage-per-node/%s", nodeID), &nodeUsage)
+	return nodeUsage, err
+}
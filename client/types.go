@@ -2,14 +2,23 @@ package client
 
 import (
 	"github.com/vmware/go-vmware-nsxt/administration"
+	"github.com/vmware/go-vmware-nsxt/licensing"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/go-vmware-nsxt/trust"
+	"github.com/vmware/go-vmware-nsxt/upgrade"
 )
 
 // LogicalPortClient represents API group logical port for NSX-T client.
 type LogicalPortClient interface {
 	ListLogicalPorts(localVarOptionals map[string]interface{}) (manager.LogicalPortListResult, error)
 	GetLogicalPortOperationalStatus(lportID string, localVarOptionals map[string]interface{}) (manager.LogicalPortOperationalStatus, error)
+	ListAllLogicalPortOperationalStatuses() ([]manager.LogicalPortOperationalStatus, error)
+	GetLogicalPortStatistics(lportID string) (manager.LogicalPortStatistics, error)
+	GetLogicalPortState(lportID string) (manager.LogicalPortState, error)
+	ListAllVirtualMachines() ([]manager.VirtualMachine, error)
+	ListAllVifs() ([]manager.VirtualNetworkInterface, error)
+	ListAllTransportNodes() ([]manager.TransportNode, error)
 }
 
 // LogicalRouterClient represents API group logical router for NSX-T client.
@@ -31,6 +40,9 @@ type DHCPClient interface {
 	ListAllDHCPServers() ([]manager.LogicalDhcpServer, error)
 	GetDhcpStatus(dhcpID string, localVarOptionals map[string]interface{}) (manager.DhcpServerStatus, error)
 	GetDHCPStatistic(dhcpID string) (manager.DhcpStatistics, error)
+	GetDHCPLeaseInfo(dhcpID string) (manager.DhcpLeases, error)
+	ListAllDHCPIPPools(dhcpID string) ([]manager.DhcpIpPool, error)
+	ListAllDHCPStaticBindings(dhcpID string) ([]manager.DhcpStaticBinding, error)
 }
 
 // TransportNodeClient represents API group Transport Node for NSX-T client.
@@ -40,6 +52,103 @@ type TransportNodeClient interface {
 	ListAllEdgeClusters() ([]manager.EdgeCluster, error)
 }
 
+// TransportNodeSystemClient represents API group Transport Node system status for NSX-T client.
+type TransportNodeSystemClient interface {
+	ListAllTransportNodes() ([]manager.TransportNode, error)
+	ListAllEdgeClusters() ([]manager.EdgeCluster, error)
+	GetTransportNodeSystemStatus(nodeID string) (manager.NodeStatus, error)
+	GetEdgeDatapathCPUStats(transportNodeID string) (DatapathCPUStats, error)
+}
+
+// TransportNodeInterfaceClient represents API group Transport Node network interfaces for NSX-T client.
+type TransportNodeInterfaceClient interface {
+	ListAllTransportNodes() ([]manager.TransportNode, error)
+	ListTransportNodeInterfaces(nodeID string) ([]manager.NodeInterfaceProperties, error)
+	GetTransportNodeInterfaceStatistics(nodeID, interfaceID string) (manager.NodeInterfaceStatisticsProperties, error)
+}
+
+// TransportZoneClient represents API group Transport Zone for NSX-T client.
+type TransportZoneClient interface {
+	ListAllTransportZones() ([]manager.TransportZone, error)
+	GetTransportZoneStatus(zoneID string) (manager.TransportZoneStatus, error)
+}
+
+// EdgeClusterClient represents API group Edge Cluster for NSX-T client.
+type EdgeClusterClient interface {
+	ListAllEdgeClusters() ([]manager.EdgeCluster, error)
+	GetTransportNodeStatus(nodeID string) (manager.TransportNodeStatus, error)
+	GetEdgeClusterAllocationStatus(edgeClusterID string) (EdgeClusterAllocationStatus, error)
+}
+
+// ComputeManagerClient represents API group Compute Manager for NSX-T client.
+type ComputeManagerClient interface {
+	ListAllComputeManagers() ([]manager.ComputeManager, error)
+	GetComputeManagerStatus(computeManagerID string) (manager.ComputeManagerStatus, error)
+}
+
+// CertificateClient represents API group trust management certificate for NSX-T client.
+type CertificateClient interface {
+	ListAllCertificates() ([]trust.Certificate, error)
+}
+
+// LicenseClient represents API group licensing for NSX-T client.
+type LicenseClient interface {
+	ListAllLicenses() ([]licensing.License, error)
+	GetLicenseUsageReport() (licensing.FeatureUsageList, error)
+}
+
+// BackupClient represents API group cluster backup for NSX-T client.
+type BackupClient interface {
+	GetBackupConfig() (administration.BackupConfiguration, error)
+	GetBackupHistory() (administration.BackupOperationHistory, error)
+}
+
+// AlarmClient represents API group alarm for NSX-T client.
+type AlarmClient interface {
+	ReadNodeProperties() (manager.NodeProperties, error)
+	ListAllAlarms() ([]Alarm, error)
+}
+
+// UpgradeClient represents API group upgrade coordinator for NSX-T client.
+type UpgradeClient interface {
+	GetUpgradeSummary() (upgrade.UpgradeSummary, error)
+	GetUpgradeStatusSummary() (upgrade.UpgradeStatus, error)
+	ListAllUpgradeUnitGroupStatuses(componentType string) ([]upgrade.UpgradeUnitGroupStatus, error)
+	GetUpgradeUnitsStats() ([]upgrade.UpgradeUnitTypeStats, error)
+}
+
+// PoolManagementClient represents API group pool management for NSX-T client.
+type PoolManagementClient interface {
+	ListAllIPPools() ([]manager.IpPool, error)
+	ListIPPoolAllocations(poolID string) ([]manager.AllocationIpAddress, error)
+	ListAllIPBlocks() ([]manager.IpBlock, error)
+	ListAllIPBlockSubnets() ([]manager.IpBlockSubnet, error)
+}
+
+// IPSecVPNClient represents API group IPSec VPN for NSX-T client.
+type IPSecVPNClient interface {
+	ListAllIPSecVPNSessions() ([]IPSecVPNSession, error)
+	ListAllIPSecVPNLocalEndpoints() ([]IPSecVPNLocalEndpoint, error)
+	ListAllIPSecVPNPeerEndpoints() ([]IPSecVPNPeerEndpoint, error)
+	GetIPSecVPNSessionStatus(sessionID string) (IPSecVPNSessionStatus, error)
+	GetIPSecVPNSessionStatistics(sessionID string) (IPSecVPNSessionStatistics, error)
+}
+
+// L2VPNClient represents API group L2VPN for NSX-T client.
+type L2VPNClient interface {
+	ListAllL2VPNSessions() ([]L2VPNSession, error)
+	GetL2VPNSessionStatus(sessionID string) (L2VPNSessionStatus, error)
+	GetL2VPNSessionStatistics(sessionID string) (L2VPNSessionStatistics, error)
+}
+
+// LoadBalancerUsageClient represents API group Load Balancer usage for NSX-T client.
+type LoadBalancerUsageClient interface {
+	ListAllLoadBalancers() ([]loadbalancer.LbService, error)
+	ListAllEdgeClusters() ([]manager.EdgeCluster, error)
+	GetLoadBalancerServiceUsage(loadBalancerID string) (LoadBalancerServiceUsage, error)
+	GetLoadBalancerNodeUsage(nodeID string) (LoadBalancerNodeUsage, error)
+}
+
 // SystemClient represents API group system for NSX-t client.
 type SystemClient interface {
 	ReadClusterStatus() (administration.ClusterStatus, error)
@@ -63,11 +172,18 @@ type LogicalSwitchClient interface {
 	ListAllLogicalSwitches() ([]manager.LogicalSwitch, error)
 	GetLogicalSwitchState(lswitchID string) (manager.LogicalSwitchState, error)
 	GetLogicalSwitchStatistic(lswitchID string) (manager.LogicalSwitchStatistics, error)
+	ListAllLogicalSwitchMacTableEntries(lswitchID string, transportNodeID string) ([]manager.MacTableEntry, error)
+	ListAllLogicalSwitchVtepTableEntries(lswitchID string, transportNodeID string) ([]manager.VtepTableEntry, error)
+	ListAllLogicalPorts() ([]manager.LogicalPort, error)
+	ListAllTransportNodes() ([]manager.TransportNode, error)
 }
 
 // LoadBalancerClient represents API group Load Balancer for NSXT-T Client
 type LoadBalancerClient interface {
 	ListAllLoadBalancers() ([]loadbalancer.LbService, error)
+	ListAllLoadBalancerVirtualServers() ([]loadbalancer.LbVirtualServer, error)
+	ListAllLoadBalancerPools() ([]loadbalancer.LbPool, error)
+	ListAllLoadBalancerMonitors() ([]LoadBalancerMonitor, error)
 	GetLoadBalancerStatus(loadBalancerID string) (loadbalancer.LbServiceStatus, error)
 	GetLoadBalancerStatistic(loadBalancerID string) (loadbalancer.LbServiceStatistics, error)
 }
@@ -76,5 +192,5 @@ type LoadBalancerClient interface {
 type FirewallClient interface {
 	ListAllFirewallSections() ([]manager.FirewallSection, error)
 	GetAllFirewallRules(sectionId string) ([]manager.FirewallRule, error)
-	GetFirewallStats(sectionId string, ruleId string) (manager.FirewallStats, error)
+	GetFirewallStats(sectionId string, ruleId string) (FirewallStats, error)
 }
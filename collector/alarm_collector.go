@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// alarmMinimumMajorVersion is the first NSX-T major version providing the alarm framework.
+const alarmMinimumMajorVersion = 3
+
+var (
+	alarmDetail = kingpin.Flag("collector.alarm.detail", "Export one series per open alarm with its entity ID.").Default("false").Bool()
+)
+
+func init() {
+	registerCollector("alarm", createAlarmCollectorFactory)
+}
+
+type alarmCollector struct {
+	alarmClient client.AlarmClient
+	logger      log.Logger
+	detail      bool
+
+	mutex     sync.Mutex
+	supported *bool
+
+	alarmsOpen *prometheus.Desc
+	alarmInfo  *prometheus.Desc
+}
+
+type alarmCountMetric struct {
+	Feature   string
+	EventType string
+	Severity  string
+	Status    string
+	Count     float64
+}
+
+type alarmDetailMetric struct {
+	ID         string
+	Feature    string
+	EventType  string
+	Severity   string
+	Status     string
+	EntityID   string
+	EntityType string
+	NodeID     string
+}
+
+func createAlarmCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newAlarmCollector(nsxtClient, logger, *alarmDetail)
+}
+
+func newAlarmCollector(alarmClient client.AlarmClient, logger log.Logger, detail bool) *alarmCollector {
+	alarmsOpen := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "alarms", "open"),
+		"Number of open alarms",
+		[]string{"feature", "event_type", "severity", "status"},
+		nil,
+	)
+	alarmInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "alarm", "info"),
+		"Info of open alarm",
+		[]string{"id", "feature", "event_type", "severity", "status", "entity_id", "entity_type", "node_id"},
+		nil,
+	)
+	return &alarmCollector{
+		alarmClient: alarmClient,
+		logger:      logger,
+		detail:      detail,
+
+		alarmsOpen: alarmsOpen,
+		alarmInfo:  alarmInfo,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *alarmCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.alarmsOpen
+	ch <- c.alarmInfo
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *alarmCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.isSupported() {
+		return
+	}
+	alarms, err := c.alarmClient.ListAllAlarms()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list alarms", "err", err)
+		return
+	}
+	for _, m := range c.generateAlarmCountMetrics(alarms) {
+		ch <- prometheus.MustNewConstMetric(c.alarmsOpen, prometheus.GaugeValue, m.Count, m.Feature, m.EventType, m.Severity, m.Status)
+	}
+	if !c.detail {
+		return
+	}
+	for _, m := range c.generateAlarmDetailMetrics(alarms) {
+		ch <- prometheus.MustNewConstMetric(c.alarmInfo, prometheus.GaugeValue, 1.0, m.ID, m.Feature, m.EventType, m.Severity, m.Status, m.EntityID, m.EntityType, m.NodeID)
+	}
+}
+
+// isSupported detects once whether NSX-T manager provides the alarm framework.
+// Detection is retried on the next scrape when the manager version can not be read.
+func (c *alarmCollector) isSupported() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.supported != nil {
+		return *c.supported
+	}
+	nodeProperties, err := c.alarmClient.ReadNodeProperties()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to read node properties", "err", err)
+		return false
+	}
+	majorVersion, err := parseMajorVersion(nodeProperties.NodeVersion)
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to parse node version", "version", nodeProperties.NodeVersion, "err", err)
+		return false
+	}
+	supported := majorVersion >= alarmMinimumMajorVersion
+	if !supported {
+		level.Info(c.logger).Log("msg", "Alarm framework is not available, disabling alarm collector", "version", nodeProperties.NodeVersion)
+	}
+	c.supported = &supported
+	return supported
+}
+
+func (c *alarmCollector) generateAlarmCountMetrics(alarms []client.Alarm) (alarmCountMetrics []alarmCountMetric) {
+	alarmCounts := make(map[alarmCountMetric]float64)
+	for _, alarm := range alarms {
+		if !isAlarmOpen(alarm) {
+			continue
+		}
+		key := alarmCountMetric{
+			Feature:   alarm.FeatureName,
+			EventType: alarm.EventType,
+			Severity:  alarm.Severity,
+			Status:    alarm.Status,
+		}
+		alarmCounts[key]++
+	}
+	for alarmCountMetric, count := range alarmCounts {
+		alarmCountMetric.Count = count
+		alarmCountMetrics = append(alarmCountMetrics, alarmCountMetric)
+	}
+	return
+}
+
+func (c *alarmCollector) generateAlarmDetailMetrics(alarms []client.Alarm) (alarmDetailMetrics []alarmDetailMetric) {
+	for _, alarm := range alarms {
+		if !isAlarmOpen(alarm) {
+			continue
+		}
+		alarmDetailMetric := alarmDetailMetric{
+			ID:         alarm.ID,
+			Feature:    alarm.FeatureName,
+			EventType:  alarm.EventType,
+			Severity:   alarm.Severity,
+			Status:     alarm.Status,
+			EntityID:   alarm.EntityID,
+			EntityType: alarm.EntityResourceType,
+			NodeID:     alarm.NodeID,
+		}
+		alarmDetailMetrics = append(alarmDetailMetrics, alarmDetailMetric)
+	}
+	return
+}
+
+func isAlarmOpen(alarm client.Alarm) bool {
+	return strings.ToUpper(alarm.Status) != "RESOLVED"
+}
+
+// parseMajorVersion returns the major version of NSX-T node version such as 3.0.1.0.0.16404613.
+func parseMajorVersion(version string) (int, error) {
+	majorVersion, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid version %q", version)
+	}
+	return majorVersion, nil
+}
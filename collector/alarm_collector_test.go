@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func fakeAlarmID(id string) string {
+	return "fake-alarm-id-" + id
+}
+
+type mockAlarmClient struct {
+	nodeVersion             string
+	nodePropertiesError     error
+	readNodePropertiesCalls int
+}
+
+func (c *mockAlarmClient) ReadNodeProperties() (manager.NodeProperties, error) {
+	c.readNodePropertiesCalls++
+	return manager.NodeProperties{NodeVersion: c.nodeVersion}, c.nodePropertiesError
+}
+
+func (c *mockAlarmClient) ListAllAlarms() ([]client.Alarm, error) {
+	panic("unused function. Only used to satisfy AlarmClient interface")
+}
+
+func buildAlarms() []client.Alarm {
+	return []client.Alarm{
+		{
+			ID:                 fakeAlarmID("01"),
+			FeatureName:        "certificates",
+			EventType:          "certificate_expiration_approaching",
+			Severity:           "MEDIUM",
+			Status:             "OPEN",
+			EntityID:           "fake-certificate-id-01",
+			EntityResourceType: "Certificate",
+			NodeID:             "fake-node-id-01",
+		}, {
+			ID:                 fakeAlarmID("02"),
+			FeatureName:        "certificates",
+			EventType:          "certificate_expiration_approaching",
+			Severity:           "MEDIUM",
+			Status:             "OPEN",
+			EntityID:           "fake-certificate-id-02",
+			EntityResourceType: "Certificate",
+			NodeID:             "fake-node-id-01",
+		}, {
+			ID:          fakeAlarmID("03"),
+			FeatureName: "edge_health",
+			EventType:   "edge_cpu_usage_high",
+			Severity:    "HIGH",
+			Status:      "ACKNOWLEDGED",
+			EntityID:    "fake-node-id-02",
+			NodeID:      "fake-node-id-02",
+		}, {
+			ID:          fakeAlarmID("04"),
+			FeatureName: "infrastructure_service",
+			EventType:   "ntp_service_down",
+			Severity:    "HIGH",
+			Status:      "RESOLVED",
+			EntityID:    "fake-node-id-03",
+			NodeID:      "fake-node-id-03",
+		},
+	}
+}
+
+func TestAlarmCollector_GenerateAlarmCountMetrics(t *testing.T) {
+	expectedMetrics := []alarmCountMetric{
+		{
+			Feature:   "certificates",
+			EventType: "certificate_expiration_approaching",
+			Severity:  "MEDIUM",
+			Status:    "OPEN",
+			Count:     2,
+		}, {
+			Feature:   "edge_health",
+			EventType: "edge_cpu_usage_high",
+			Severity:  "HIGH",
+			Status:    "ACKNOWLEDGED",
+			Count:     1,
+		},
+	}
+	mockClient := &mockAlarmClient{}
+	logger := log.NewNopLogger()
+	collector := newAlarmCollector(mockClient, logger, false)
+	metrics := collector.generateAlarmCountMetrics(buildAlarms())
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
+
+func TestAlarmCollector_GenerateAlarmDetailMetrics(t *testing.T) {
+	expectedMetrics := []alarmDetailMetric{
+		{
+			ID:         fakeAlarmID("01"),
+			Feature:    "certificates",
+			EventType:  "certificate_expiration_approaching",
+			Severity:   "MEDIUM",
+			Status:     "OPEN",
+			EntityID:   "fake-certificate-id-01",
+			EntityType: "Certificate",
+			NodeID:     "fake-node-id-01",
+		}, {
+			ID:         fakeAlarmID("02"),
+			Feature:    "certificates",
+			EventType:  "certificate_expiration_approaching",
+			Severity:   "MEDIUM",
+			Status:     "OPEN",
+			EntityID:   "fake-certificate-id-02",
+			EntityType: "Certificate",
+			NodeID:     "fake-node-id-01",
+		}, {
+			ID:        fakeAlarmID("03"),
+			Feature:   "edge_health",
+			EventType: "edge_cpu_usage_high",
+			Severity:  "HIGH",
+			Status:    "ACKNOWLEDGED",
+			EntityID:  "fake-node-id-02",
+			NodeID:    "fake-node-id-02",
+		},
+	}
+	mockClient := &mockAlarmClient{}
+	logger := log.NewNopLogger()
+	collector := newAlarmCollector(mockClient, logger, true)
+	metrics := collector.generateAlarmDetailMetrics(buildAlarms())
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
+
+func TestAlarmCollector_IsSupported(t *testing.T) {
+	testcases := []struct {
+		description                     string
+		nodeVersion                     string
+		nodePropertiesError             error
+		expectedSupported               bool
+		expectedReadNodePropertiesCalls int
+	}{
+		{
+			description:                     "Should be supported on NSX-T 3.x",
+			nodeVersion:                     "3.0.1.0.0.16404613",
+			expectedSupported:               true,
+			expectedReadNodePropertiesCalls: 1,
+		}, {
+			description:                     "Should not be supported on NSX-T 2.x",
+			nodeVersion:                     "2.5.1.0.0.15314288",
+			expectedSupported:               false,
+			expectedReadNodePropertiesCalls: 1,
+		}, {
+			description:                     "Should retry detection when node properties are unavailable",
+			nodePropertiesError:             errors.New("error reading node properties"),
+			expectedSupported:               false,
+			expectedReadNodePropertiesCalls: 2,
+		}, {
+			description:                     "Should retry detection when node version is invalid",
+			nodeVersion:                     "invalid",
+			expectedSupported:               false,
+			expectedReadNodePropertiesCalls: 2,
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockAlarmClient{
+			nodeVersion:         tc.nodeVersion,
+			nodePropertiesError: tc.nodePropertiesError,
+		}
+		logger := log.NewNopLogger()
+		collector := newAlarmCollector(mockClient, logger, false)
+		assert.Equal(t, tc.expectedSupported, collector.isSupported(), tc.description)
+		assert.Equal(t, tc.expectedSupported, collector.isSupported(), tc.description)
+		assert.Equal(t, tc.expectedReadNodePropertiesCalls, mockClient.readNodePropertiesCalls, tc.description)
+	}
+}
@@ -0,0 +1,165 @@
+package collector
+
+import (
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/administration"
+)
+
+func init() {
+	registerCollector("backup", createBackupCollectorFactory)
+}
+
+type backupCollector struct {
+	backupClient client.BackupClient
+	logger       log.Logger
+
+	backupEnabled            *prometheus.Desc
+	backupScheduleConfigured *prometheus.Desc
+	backupLastSuccess        *prometheus.Desc
+	backupLastFailure        *prometheus.Desc
+	backupLastAttemptSuccess *prometheus.Desc
+}
+
+type backupMetric struct {
+	Type                 string
+	LastSuccessTimestamp float64
+	LastFailureTimestamp float64
+	LastAttemptSuccess   float64
+	LastAttemptErrorCode string
+}
+
+func createBackupCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newBackupCollector(nsxtClient, logger)
+}
+
+func newBackupCollector(backupClient client.BackupClient, logger log.Logger) *backupCollector {
+	backupEnabled := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backup", "enabled"),
+		"Whether automated backup is enabled",
+		nil,
+		nil,
+	)
+	backupScheduleConfigured := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backup", "schedule_configured"),
+		"Whether backup schedule is configured",
+		nil,
+		nil,
+	)
+	backupLastSuccess := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backup", "last_success_timestamp_seconds"),
+		"Timestamp of last successful backup, 0 if there is none",
+		[]string{"type"},
+		nil,
+	)
+	backupLastFailure := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backup", "last_failure_timestamp_seconds"),
+		"Timestamp of last failed backup, 0 if there is none",
+		[]string{"type"},
+		nil,
+	)
+	backupLastAttemptSuccess := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "backup", "last_attempt_success"),
+		"Whether the most recent backup attempt succeeded",
+		[]string{"type", "error_code"},
+		nil,
+	)
+	return &backupCollector{
+		backupClient: backupClient,
+		logger:       logger,
+
+		backupEnabled:            backupEnabled,
+		backupScheduleConfigured: backupScheduleConfigured,
+		backupLastSuccess:        backupLastSuccess,
+		backupLastFailure:        backupLastFailure,
+		backupLastAttemptSuccess: backupLastAttemptSuccess,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *backupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.backupEnabled
+	ch <- c.backupScheduleConfigured
+	ch <- c.backupLastSuccess
+	ch <- c.backupLastFailure
+	ch <- c.backupLastAttemptSuccess
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *backupCollector) Collect(ch chan<- prometheus.Metric) {
+	backupConfig, err := c.backupClient.GetBackupConfig()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get backup config", "err", err)
+	} else {
+		backupEnabled := 0.0
+		if backupConfig.BackupEnabled {
+			backupEnabled = 1.0
+		}
+		backupScheduleConfigured := 0.0
+		if backupConfig.BackupSchedule != nil {
+			backupScheduleConfigured = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.backupEnabled, prometheus.GaugeValue, backupEnabled)
+		ch <- prometheus.MustNewConstMetric(c.backupScheduleConfigured, prometheus.GaugeValue, backupScheduleConfigured)
+	}
+	backupHistory, err := c.backupClient.GetBackupHistory()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get backup history", "err", err)
+		return
+	}
+	for _, m := range c.generateBackupMetrics(backupHistory) {
+		ch <- prometheus.MustNewConstMetric(c.backupLastSuccess, prometheus.GaugeValue, m.LastSuccessTimestamp, m.Type)
+		ch <- prometheus.MustNewConstMetric(c.backupLastFailure, prometheus.GaugeValue, m.LastFailureTimestamp, m.Type)
+		ch <- prometheus.MustNewConstMetric(c.backupLastAttemptSuccess, prometheus.GaugeValue, m.LastAttemptSuccess, m.Type, m.LastAttemptErrorCode)
+	}
+}
+
+func (c *backupCollector) generateBackupMetrics(backupHistory administration.BackupOperationHistory) (backupMetrics []backupMetric) {
+	backupStatusesByType := map[string][]administration.BackupOperationStatus{
+		"cluster":   backupHistory.ClusterBackupStatuses,
+		"node":      backupHistory.NodeBackupStatuses,
+		"inventory": backupHistory.InventoryBackupStatuses,
+	}
+	for backupType, backupStatuses := range backupStatusesByType {
+		if len(backupStatuses) == 0 {
+			continue
+		}
+		backupMetric := backupMetric{
+			Type: backupType,
+		}
+		var lastAttemptTimestamp float64
+		for _, backupStatus := range backupStatuses {
+			timestamp := backupOperationTimestamp(backupStatus)
+			if backupStatus.Success && timestamp > backupMetric.LastSuccessTimestamp {
+				backupMetric.LastSuccessTimestamp = timestamp
+			}
+			if !backupStatus.Success && timestamp > backupMetric.LastFailureTimestamp {
+				backupMetric.LastFailureTimestamp = timestamp
+			}
+			if timestamp >= lastAttemptTimestamp {
+				lastAttemptTimestamp = timestamp
+				backupMetric.LastAttemptSuccess = 0.0
+				if backupStatus.Success {
+					backupMetric.LastAttemptSuccess = 1.0
+				}
+				backupMetric.LastAttemptErrorCode = backupStatus.ErrorCode
+			}
+		}
+		backupMetrics = append(backupMetrics, backupMetric)
+	}
+	return
+}
+
+// backupOperationTimestamp returns the end time of backup operation in seconds,
+// falling back to start time for operations without end time.
+func backupOperationTimestamp(backupStatus administration.BackupOperationStatus) float64 {
+	if backupStatus.EndTime != 0 {
+		return float64(backupStatus.EndTime) / 1000
+	}
+	return float64(backupStatus.StartTime) / 1000
+}
@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/administration"
+)
+
+type mockBackupClient struct{}
+
+func (c *mockBackupClient) GetBackupConfig() (administration.BackupConfiguration, error) {
+	panic("unused function. Only used to satisfy BackupClient interface")
+}
+
+func (c *mockBackupClient) GetBackupHistory() (administration.BackupOperationHistory, error) {
+	panic("unused function. Only used to satisfy BackupClient interface")
+}
+
+func TestBackupCollector_GenerateBackupMetrics(t *testing.T) {
+	testcases := []struct {
+		description     string
+		backupHistory   administration.BackupOperationHistory
+		expectedMetrics []backupMetric
+	}{
+		{
+			description: "Should return last success, last failure and last attempt per backup type",
+			backupHistory: administration.BackupOperationHistory{
+				ClusterBackupStatuses: []administration.BackupOperationStatus{
+					{
+						StartTime: 1590969000000,
+						EndTime:   1590969600000,
+						Success:   true,
+					}, {
+						StartTime: 1591056000000,
+						EndTime:   1591056600000,
+						Success:   false,
+						ErrorCode: "BACKUP_SERVER_UNREACHABLE",
+					},
+				},
+				NodeBackupStatuses: []administration.BackupOperationStatus{
+					{
+						StartTime: 1591056000000,
+						EndTime:   1591056600000,
+						Success:   true,
+					},
+				},
+				InventoryBackupStatuses: []administration.BackupOperationStatus{
+					{
+						StartTime: 1591056000000,
+						Success:   false,
+						ErrorCode: "BACKUP_GENERIC_ERROR",
+					},
+				},
+			},
+			expectedMetrics: []backupMetric{
+				{
+					Type:                 "cluster",
+					LastSuccessTimestamp: 1590969600,
+					LastFailureTimestamp: 1591056600,
+					LastAttemptSuccess:   0.0,
+					LastAttemptErrorCode: "BACKUP_SERVER_UNREACHABLE",
+				}, {
+					Type:                 "node",
+					LastSuccessTimestamp: 1591056600,
+					LastFailureTimestamp: 0,
+					LastAttemptSuccess:   1.0,
+				}, {
+					Type:                 "inventory",
+					LastSuccessTimestamp: 0,
+					LastFailureTimestamp: 1591056000,
+					LastAttemptSuccess:   0.0,
+					LastAttemptErrorCode: "BACKUP_GENERIC_ERROR",
+				},
+			},
+		}, {
+			description:     "Should return empty metrics when there is no backup history",
+			backupHistory:   administration.BackupOperationHistory{},
+			expectedMetrics: []backupMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockBackupClient{}
+		logger := log.NewNopLogger()
+		collector := newBackupCollector(mockClient, logger)
+		metrics := collector.generateBackupMetrics(tc.backupHistory)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
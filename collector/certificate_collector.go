@@ -0,0 +1,139 @@
+package collector
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"sort"
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/trust"
+)
+
+func init() {
+	registerCollector("certificate", createCertificateCollectorFactory)
+}
+
+type certificateCollector struct {
+	certificateClient client.CertificateClient
+	logger            log.Logger
+
+	certificateNotBefore *prometheus.Desc
+	certificateNotAfter  *prometheus.Desc
+}
+
+type certificateMetric struct {
+	ID        string
+	Name      string
+	SubjectCN string
+	Issuer    string
+	Usage     string
+	NotBefore float64
+	NotAfter  float64
+}
+
+func createCertificateCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newCertificateCollector(nsxtClient, logger)
+}
+
+func newCertificateCollector(certificateClient client.CertificateClient, logger log.Logger) *certificateCollector {
+	certificateNotBefore := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "certificate", "not_before_timestamp_seconds"),
+		"Timestamp from which certificate is valid",
+		[]string{"id", "name", "subject_cn", "issuer", "usage"},
+		nil,
+	)
+	certificateNotAfter := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "certificate", "not_after_timestamp_seconds"),
+		"Timestamp after which certificate expires",
+		[]string{"id", "name", "subject_cn", "issuer", "usage"},
+		nil,
+	)
+	return &certificateCollector{
+		certificateClient: certificateClient,
+		logger:            logger,
+
+		certificateNotBefore: certificateNotBefore,
+		certificateNotAfter:  certificateNotAfter,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *certificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.certificateNotBefore
+	ch <- c.certificateNotAfter
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *certificateCollector) Collect(ch chan<- prometheus.Metric) {
+	certificates, err := c.certificateClient.ListAllCertificates()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list certificates", "err", err)
+		return
+	}
+	certificateMetrics := c.generateCertificateMetrics(certificates)
+	for _, m := range certificateMetrics {
+		labels := []string{m.ID, m.Name, m.SubjectCN, m.Issuer, m.Usage}
+		ch <- prometheus.MustNewConstMetric(c.certificateNotBefore, prometheus.GaugeValue, m.NotBefore, labels...)
+		ch <- prometheus.MustNewConstMetric(c.certificateNotAfter, prometheus.GaugeValue, m.NotAfter, labels...)
+	}
+}
+
+func (c *certificateCollector) generateCertificateMetrics(certificates []trust.Certificate) (certificateMetrics []certificateMetric) {
+	for _, certificate := range certificates {
+		x509Certificate, err := parseLeafCertificate(certificate.PemEncoded)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to parse certificate", "id", certificate.Id, "err", err)
+			continue
+		}
+		certificateMetric := certificateMetric{
+			ID:        certificate.Id,
+			Name:      certificate.DisplayName,
+			SubjectCN: x509Certificate.Subject.CommonName,
+			Issuer:    x509Certificate.Issuer.String(),
+			Usage:     certificateUsage(certificate.UsedBy),
+			NotBefore: float64(x509Certificate.NotBefore.Unix()),
+			NotAfter:  float64(x509Certificate.NotAfter.Unix()),
+		}
+		certificateMetrics = append(certificateMetrics, certificateMetric)
+	}
+	return
+}
+
+// parseLeafCertificate parses the first certificate of a PEM encoded certificate chain.
+func parseLeafCertificate(pemEncoded string) (*x509.Certificate, error) {
+	rest := []byte(pemEncoded)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM encoded certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// certificateUsage returns the sorted, comma separated service types which use the certificate.
+func certificateUsage(usedBy []trust.NodeIdServicesMap) string {
+	serviceTypes := make(map[string]bool)
+	for _, node := range usedBy {
+		for _, serviceType := range node.ServiceTypes {
+			serviceTypes[serviceType] = true
+		}
+	}
+	var usage []string
+	for serviceType := range serviceTypes {
+		usage = append(usage, serviceType)
+	}
+	sort.Strings(usage)
+	return strings.Join(usage, ",")
+}
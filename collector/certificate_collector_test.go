@@ -0,0 +1,111 @@
+package collector
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/trust"
+)
+
+const (
+	fakeCertificateSubjectCN = "nsx-manager.example.com"
+	fakeCertificateIssuer    = "CN=fake-ca,O=Example"
+	fakeCertificateNotBefore = 1590969600
+	fakeCertificateNotAfter  = 1622505600
+)
+
+func fakeCertificateID(id string) string {
+	return "fake-certificate-id-" + id
+}
+
+func fakeCertificateName(id string) string {
+	return "fake-certificate-name-" + id
+}
+
+type mockCertificateClient struct{}
+
+func (c *mockCertificateClient) ListAllCertificates() ([]trust.Certificate, error) {
+	panic("unused function. Only used to satisfy CertificateClient interface")
+}
+
+func readFixtureCertificateChain(t *testing.T) string {
+	pemEncoded, err := ioutil.ReadFile("testdata/certificate_chain.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pemEncoded)
+}
+
+func buildExpectedCertificateMetric(id string, usage string) certificateMetric {
+	return certificateMetric{
+		ID:        fakeCertificateID(id),
+		Name:      fakeCertificateName(id),
+		SubjectCN: fakeCertificateSubjectCN,
+		Issuer:    fakeCertificateIssuer,
+		Usage:     usage,
+		NotBefore: fakeCertificateNotBefore,
+		NotAfter:  fakeCertificateNotAfter,
+	}
+}
+
+func TestCertificateCollector_GenerateCertificateMetrics(t *testing.T) {
+	pemEncoded := readFixtureCertificateChain(t)
+	testcases := []struct {
+		description     string
+		certificates    []trust.Certificate
+		expectedMetrics []certificateMetric
+	}{
+		{
+			description: "Should return validity of leaf certificate with usage",
+			certificates: []trust.Certificate{
+				{
+					Id:          fakeCertificateID("01"),
+					DisplayName: fakeCertificateName("01"),
+					PemEncoded:  pemEncoded,
+					UsedBy: []trust.NodeIdServicesMap{
+						{
+							NodeId:       "fake-node-id-01",
+							ServiceTypes: []string{"API", "MGMT_CLUSTER"},
+						}, {
+							NodeId:       "fake-node-id-02",
+							ServiceTypes: []string{"API"},
+						},
+					},
+				}, {
+					Id:          fakeCertificateID("02"),
+					DisplayName: fakeCertificateName("02"),
+					PemEncoded:  pemEncoded,
+				},
+			},
+			expectedMetrics: []certificateMetric{
+				buildExpectedCertificateMetric("01", "API,MGMT_CLUSTER"),
+				buildExpectedCertificateMetric("02", ""),
+			},
+		}, {
+			description: "Should skip certificates with invalid PEM",
+			certificates: []trust.Certificate{
+				{
+					Id:          fakeCertificateID("01"),
+					DisplayName: fakeCertificateName("01"),
+					PemEncoded:  "invalid",
+				}, {
+					Id:          fakeCertificateID("02"),
+					DisplayName: fakeCertificateName("02"),
+					PemEncoded:  pemEncoded,
+				},
+			},
+			expectedMetrics: []certificateMetric{
+				buildExpectedCertificateMetric("02", ""),
+			},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockCertificateClient{}
+		logger := log.NewNopLogger()
+		collector := newCertificateCollector(mockClient, logger)
+		metrics := collector.generateCertificateMetrics(tc.certificates)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
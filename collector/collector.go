@@ -1,9 +1,11 @@
 package collector
 
 import (
+	"regexp"
 	"sync"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 )
@@ -20,6 +22,17 @@ func registerCollector(collector string, factory func(client *nsxt.APIClient, lo
 	factories[collector] = factory
 }
 
+// compileIncludeFlag compiles the regular expression given in an include flag, anchored to the whole value.
+// An invalid expression is logged and nil is returned, which disables whatever the flag selects.
+func compileIncludeFlag(flagName string, expr string, logger log.Logger) *regexp.Regexp {
+	include, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		level.Error(logger).Log("msg", "Invalid regular expression in flag, disabling what it selects", "flag", flagName, "err", err)
+		return nil
+	}
+	return include
+}
+
 // nsxtCollector collects NSX-T stats from the given api server and exports them using
 // the prometheus metrics package.
 type nsxtCollector struct {
@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+var computeManagerPossibleRegistrationStatus = [...]string{"REGISTERED", "UNREGISTERED", "REGISTERING", "REGISTERED_WITH_ERRORS"}
+var computeManagerPossibleConnectionStatus = [...]string{"UP", "DOWN", "CONNECTING"}
+
+func init() {
+	registerCollector("compute_manager", createComputeManagerCollectorFactory)
+}
+
+type computeManagerCollector struct {
+	computeManagerClient client.ComputeManagerClient
+	logger               log.Logger
+
+	computeManagerInfo               *prometheus.Desc
+	computeManagerRegistrationStatus *prometheus.Desc
+	computeManagerConnectionStatus   *prometheus.Desc
+	computeManagerLastSync           *prometheus.Desc
+}
+
+type computeManagerMetric struct {
+	ID                       string
+	Name                     string
+	Server                   string
+	OriginType               string
+	Version                  string
+	RegistrationStatusDetail map[string]float64
+	ConnectionStatusDetail   map[string]float64
+	LastSyncTimestamp        float64
+}
+
+func createComputeManagerCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newComputeManagerCollector(nsxtClient, logger)
+}
+
+func newComputeManagerCollector(computeManagerClient client.ComputeManagerClient, logger log.Logger) *computeManagerCollector {
+	computeManagerInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "compute_manager", "info"),
+		"Info of compute manager",
+		[]string{"id", "name", "server", "origin_type", "version"},
+		nil,
+	)
+	computeManagerRegistrationStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "compute_manager", "registration_status"),
+		"Registration status of compute manager",
+		[]string{"id", "name", "status"},
+		nil,
+	)
+	computeManagerConnectionStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "compute_manager", "connection_status"),
+		"Connection status of compute manager",
+		[]string{"id", "name", "status"},
+		nil,
+	)
+	computeManagerLastSync := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "compute_manager", "last_sync_timestamp_seconds"),
+		"Timestamp of the last successful inventory sync of compute manager, absent if it has never synced",
+		[]string{"id", "name"},
+		nil,
+	)
+	return &computeManagerCollector{
+		computeManagerClient: computeManagerClient,
+		logger:               logger,
+
+		computeManagerInfo:               computeManagerInfo,
+		computeManagerRegistrationStatus: computeManagerRegistrationStatus,
+		computeManagerConnectionStatus:   computeManagerConnectionStatus,
+		computeManagerLastSync:           computeManagerLastSync,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *computeManagerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.computeManagerInfo
+	ch <- c.computeManagerRegistrationStatus
+	ch <- c.computeManagerConnectionStatus
+	ch <- c.computeManagerLastSync
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *computeManagerCollector) Collect(ch chan<- prometheus.Metric) {
+	computeManagers, err := c.computeManagerClient.ListAllComputeManagers()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list compute managers", "err", err)
+		return
+	}
+	computeManagerMetrics := c.generateComputeManagerMetrics(computeManagers)
+	for _, m := range computeManagerMetrics {
+		ch <- prometheus.MustNewConstMetric(c.computeManagerInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.Server, m.OriginType, m.Version)
+		for status, value := range m.RegistrationStatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.computeManagerRegistrationStatus, prometheus.GaugeValue, value, m.ID, m.Name, status)
+		}
+		for status, value := range m.ConnectionStatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.computeManagerConnectionStatus, prometheus.GaugeValue, value, m.ID, m.Name, status)
+		}
+		// Compute managers which have never synced report 0, which is not a meaningful timestamp.
+		if m.LastSyncTimestamp != 0 {
+			ch <- prometheus.MustNewConstMetric(c.computeManagerLastSync, prometheus.GaugeValue, m.LastSyncTimestamp, m.ID, m.Name)
+		}
+	}
+}
+
+func (c *computeManagerCollector) generateComputeManagerMetrics(computeManagers []manager.ComputeManager) (computeManagerMetrics []computeManagerMetric) {
+	for _, computeManager := range computeManagers {
+		computeManagerStatus, err := c.computeManagerClient.GetComputeManagerStatus(computeManager.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get compute manager status", "id", computeManager.Id, "err", err)
+			continue
+		}
+		computeManagerMetric := computeManagerMetric{
+			ID:                       computeManager.Id,
+			Name:                     computeManager.DisplayName,
+			Server:                   computeManager.Server,
+			OriginType:               computeManager.OriginType,
+			Version:                  computeManagerStatus.Version,
+			RegistrationStatusDetail: map[string]float64{},
+			ConnectionStatusDetail:   map[string]float64{},
+			LastSyncTimestamp:        float64(computeManagerStatus.LastSyncTime) / 1000,
+		}
+		for _, status := range computeManagerPossibleRegistrationStatus {
+			statusValue := 0.0
+			if status == strings.ToUpper(computeManagerStatus.RegistrationStatus) {
+				statusValue = 1.0
+			}
+			computeManagerMetric.RegistrationStatusDetail[status] = statusValue
+		}
+		for _, status := range computeManagerPossibleConnectionStatus {
+			statusValue := 0.0
+			if status == strings.ToUpper(computeManagerStatus.ConnectionStatus) {
+				statusValue = 1.0
+			}
+			computeManagerMetric.ConnectionStatusDetail[status] = statusValue
+		}
+		computeManagerMetrics = append(computeManagerMetrics, computeManagerMetric)
+	}
+	return
+}
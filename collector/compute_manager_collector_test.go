@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+const (
+	fakeComputeManagerServer  = "vcenter.example.com"
+	fakeComputeManagerVersion = "6.7.0"
+)
+
+func fakeComputeManagerID(id string) string {
+	return "fake-compute-manager-id-" + id
+}
+
+func fakeComputeManagerName(id string) string {
+	return "fake-compute-manager-name-" + id
+}
+
+type computeManagerStatusResponse struct {
+	ID     string
+	Status manager.ComputeManagerStatus
+	Error  error
+}
+
+type mockComputeManagerClient struct {
+	statusResponses []computeManagerStatusResponse
+}
+
+func (c *mockComputeManagerClient) ListAllComputeManagers() ([]manager.ComputeManager, error) {
+	panic("unused function. Only used to satisfy ComputeManagerClient interface")
+}
+
+func (c *mockComputeManagerClient) GetComputeManagerStatus(computeManagerID string) (manager.ComputeManagerStatus, error) {
+	for _, res := range c.statusResponses {
+		if res.ID == computeManagerID {
+			return res.Status, res.Error
+		}
+	}
+	return manager.ComputeManagerStatus{}, errors.New("compute manager status not found")
+}
+
+func buildExpectedComputeManagerMetric(id string, registrationStatus string, connectionStatus string, lastSyncTimestamp float64) computeManagerMetric {
+	m := computeManagerMetric{
+		ID:                       fakeComputeManagerID(id),
+		Name:                     fakeComputeManagerName(id),
+		Server:                   fakeComputeManagerServer,
+		OriginType:               "vCenter",
+		Version:                  fakeComputeManagerVersion,
+		RegistrationStatusDetail: map[string]float64{},
+		ConnectionStatusDetail:   map[string]float64{},
+		LastSyncTimestamp:        lastSyncTimestamp,
+	}
+	for _, status := range computeManagerPossibleRegistrationStatus {
+		m.RegistrationStatusDetail[status] = 0.0
+	}
+	for _, status := range computeManagerPossibleConnectionStatus {
+		m.ConnectionStatusDetail[status] = 0.0
+	}
+	if registrationStatus != "" {
+		m.RegistrationStatusDetail[registrationStatus] = 1.0
+	}
+	if connectionStatus != "" {
+		m.ConnectionStatusDetail[connectionStatus] = 1.0
+	}
+	return m
+}
+
+func TestComputeManagerCollector_GenerateComputeManagerMetrics(t *testing.T) {
+	computeManagers := []manager.ComputeManager{
+		{
+			Id:          fakeComputeManagerID("01"),
+			DisplayName: fakeComputeManagerName("01"),
+			Server:      fakeComputeManagerServer,
+			OriginType:  "vCenter",
+		}, {
+			Id:          fakeComputeManagerID("02"),
+			DisplayName: fakeComputeManagerName("02"),
+			Server:      fakeComputeManagerServer,
+			OriginType:  "vCenter",
+		},
+	}
+	testcases := []struct {
+		description     string
+		statusResponses []computeManagerStatusResponse
+		expectedMetrics []computeManagerMetric
+	}{
+		{
+			description: "Should return registration status, connection status and last sync time",
+			statusResponses: []computeManagerStatusResponse{
+				{
+					ID: fakeComputeManagerID("01"),
+					Status: manager.ComputeManagerStatus{
+						RegistrationStatus: "REGISTERED",
+						ConnectionStatus:   "UP",
+						Version:            fakeComputeManagerVersion,
+						LastSyncTime:       1590000000000,
+					},
+				}, {
+					ID: fakeComputeManagerID("02"),
+					Status: manager.ComputeManagerStatus{
+						RegistrationStatus: "registered_with_errors",
+						ConnectionStatus:   "down",
+						Version:            fakeComputeManagerVersion,
+						LastSyncTime:       1590000000500,
+					},
+				},
+			},
+			expectedMetrics: []computeManagerMetric{
+				buildExpectedComputeManagerMetric("01", "REGISTERED", "UP", 1590000000),
+				buildExpectedComputeManagerMetric("02", "REGISTERED_WITH_ERRORS", "DOWN", 1590000000.5),
+			},
+		}, {
+			description: "Should only return compute managers with status",
+			statusResponses: []computeManagerStatusResponse{
+				{
+					ID: fakeComputeManagerID("01"),
+					Status: manager.ComputeManagerStatus{
+						RegistrationStatus: "REGISTERED",
+						ConnectionStatus:   "CONNECTING",
+						Version:            fakeComputeManagerVersion,
+					},
+				}, {
+					ID:    fakeComputeManagerID("02"),
+					Error: errors.New("error getting compute manager status"),
+				},
+			},
+			expectedMetrics: []computeManagerMetric{
+				buildExpectedComputeManagerMetric("01", "REGISTERED", "CONNECTING", 0),
+			},
+		}, {
+			description:     "Should return empty metrics when all compute manager status are unavailable",
+			statusResponses: []computeManagerStatusResponse{},
+			expectedMetrics: []computeManagerMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockComputeManagerClient{
+			statusResponses: tc.statusResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newComputeManagerCollector(mockClient, logger)
+		metrics := collector.generateComputeManagerMetrics(computeManagers)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
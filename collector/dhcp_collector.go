@@ -1,25 +1,38 @@
 package collector
 
 import (
+	"net"
 	"nsxt_exporter/client"
 	"strings"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
+// dhcpLeaseTimeLayout is the layout of start and expire time of DHCP leases.
+const dhcpLeaseTimeLayout = "2006/01/02 15:04:05"
+
 var dhcpPossibleStatus = [...]string{"UP", "DOWN", "ERROR", "NO_STANDBY"}
 
+var (
+	dhcpLeaseExpiryWindow = kingpin.Flag("collector.dhcp.lease_expiry_window", "Window in which DHCP leases are counted as expiring.").Default("1h").Duration()
+	dhcpStaticBindings    = kingpin.Flag("collector.dhcp.static_bindings", "Collect DHCP static binding counts.").Default("false").Bool()
+)
+
 func init() {
 	registerCollector("dhcp", createDHCPCollectorFactory)
 }
 
 type dhcpCollector struct {
-	dhcpClient client.DHCPClient
-	logger     log.Logger
+	dhcpClient        client.DHCPClient
+	logger            log.Logger
+	leaseExpiryWindow time.Duration
+	staticBindings    bool
 
 	dhcpStatus          *prometheus.Desc
 	dhcpAckPacket       *prometheus.Desc
@@ -33,6 +46,9 @@ type dhcpCollector struct {
 	dhcpRequestPacket   *prometheus.Desc
 	dhcpIPPoolSize      *prometheus.Desc
 	dhcpIPPoolAllocated *prometheus.Desc
+	dhcpActiveLeases    *prometheus.Desc
+	dhcpIPPoolExpiring  *prometheus.Desc
+	dhcpStaticBindings  *prometheus.Desc
 }
 
 type dhcpStatusMetric struct {
@@ -47,12 +63,25 @@ type dhcpStatisticMetric struct {
 	Statistic manager.DhcpStatistics
 }
 
+type dhcpLeaseMetric struct {
+	ID                   string
+	Name                 string
+	ActiveLeases         float64
+	IPPoolExpiringLeases map[string]float64
+}
+
+type dhcpStaticBindingMetric struct {
+	ID             string
+	Name           string
+	StaticBindings float64
+}
+
 func createDHCPCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
 	nsxtClient := client.NewNSXTClient(apiClient, logger)
-	return newDHCPCollector(nsxtClient, logger)
+	return newDHCPCollector(nsxtClient, logger, *dhcpLeaseExpiryWindow, *dhcpStaticBindings)
 }
 
-func newDHCPCollector(dhcpClient client.DHCPClient, logger log.Logger) *dhcpCollector {
+func newDHCPCollector(dhcpClient client.DHCPClient, logger log.Logger, leaseExpiryWindow time.Duration, staticBindings bool) *dhcpCollector {
 	dhcpStatus := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "dhcp", "status"),
 		"Status of DHCP",
@@ -125,9 +154,29 @@ func newDHCPCollector(dhcpClient client.DHCPClient, logger log.Logger) *dhcpColl
 		[]string{"id", "dhcp_id"},
 		nil,
 	)
+	dhcpActiveLeases := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dhcp", "active_leases"),
+		"Number of active leases of dhcp",
+		[]string{"id", "name"},
+		nil,
+	)
+	dhcpIPPoolExpiring := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dhcp", "ip_pool_expiring_leases"),
+		"Number of leases of dhcp ip pool expiring within lease expiry window",
+		[]string{"id", "dhcp_id"},
+		nil,
+	)
+	dhcpStaticBindings := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dhcp", "static_bindings"),
+		"Number of static bindings of dhcp",
+		[]string{"id", "name"},
+		nil,
+	)
 	return &dhcpCollector{
 		dhcpClient:          dhcpClient,
 		logger:              logger,
+		leaseExpiryWindow:   leaseExpiryWindow,
+		staticBindings:      staticBindings,
 		dhcpStatus:          dhcpStatus,
 		dhcpAckPacket:       dhcpAckPacket,
 		dhcpDeclinePacket:   dhcpDeclinePacket,
@@ -140,6 +189,9 @@ func newDHCPCollector(dhcpClient client.DHCPClient, logger log.Logger) *dhcpColl
 		dhcpRequestPacket:   dhcpRequestPacket,
 		dhcpIPPoolSize:      dhcpIPPoolSize,
 		dhcpIPPoolAllocated: dhcpIPPoolAllocated,
+		dhcpActiveLeases:    dhcpActiveLeases,
+		dhcpIPPoolExpiring:  dhcpIPPoolExpiring,
+		dhcpStaticBindings:  dhcpStaticBindings,
 	}
 }
 
@@ -157,6 +209,9 @@ func (dc *dhcpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- dc.dhcpRequestPacket
 	ch <- dc.dhcpIPPoolSize
 	ch <- dc.dhcpIPPoolAllocated
+	ch <- dc.dhcpActiveLeases
+	ch <- dc.dhcpIPPoolExpiring
+	ch <- dc.dhcpStaticBindings
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -190,6 +245,20 @@ func (dc *dhcpCollector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.MustNewConstMetric(dc.dhcpIPPoolAllocated, prometheus.GaugeValue, float64(ipPoolStat.AllocatedNumber), ipPoolLabels...)
 		}
 	}
+	dhcpLeaseMetrics := dc.generateDHCPLeaseMetrics(dhcpServers, time.Now())
+	for _, m := range dhcpLeaseMetrics {
+		ch <- prometheus.MustNewConstMetric(dc.dhcpActiveLeases, prometheus.GaugeValue, m.ActiveLeases, m.ID, m.Name)
+		for ipPoolID, expiringLeases := range m.IPPoolExpiringLeases {
+			ch <- prometheus.MustNewConstMetric(dc.dhcpIPPoolExpiring, prometheus.GaugeValue, expiringLeases, ipPoolID, m.ID)
+		}
+	}
+	if !dc.staticBindings {
+		return
+	}
+	dhcpStaticBindingMetrics := dc.generateDHCPStaticBindingMetrics(dhcpServers)
+	for _, m := range dhcpStaticBindingMetrics {
+		ch <- prometheus.MustNewConstMetric(dc.dhcpStaticBindings, prometheus.GaugeValue, m.StaticBindings, m.ID, m.Name)
+	}
 }
 
 func (dc *dhcpCollector) generateDHCPStatusMetrics(dhcpServers []manager.LogicalDhcpServer) (dhcpStatusMetrics []dhcpStatusMetric) {
@@ -232,3 +301,67 @@ func (dc *dhcpCollector) generateDHCPStatisticMetrics(dhcpServers []manager.Logi
 	}
 	return
 }
+
+func (dc *dhcpCollector) generateDHCPLeaseMetrics(dhcpServers []manager.LogicalDhcpServer, now time.Time) (dhcpLeaseMetrics []dhcpLeaseMetric) {
+	for _, dhcp := range dhcpServers {
+		dhcpLeases, err := dc.dhcpClient.GetDHCPLeaseInfo(dhcp.Id)
+		if err != nil {
+			level.Error(dc.logger).Log("msg", "Unable to get dhcp lease info", "id", dhcp.Id, "err", err)
+			continue
+		}
+		dhcpIPPools, err := dc.dhcpClient.ListAllDHCPIPPools(dhcp.Id)
+		if err != nil {
+			level.Error(dc.logger).Log("msg", "Unable to list dhcp ip pools", "id", dhcp.Id, "err", err)
+		}
+		dhcpLeaseMetric := dhcpLeaseMetric{
+			ID:                   dhcp.Id,
+			Name:                 dhcp.DisplayName,
+			IPPoolExpiringLeases: make(map[string]float64),
+		}
+		for _, dhcpIPPool := range dhcpIPPools {
+			dhcpLeaseMetric.IPPoolExpiringLeases[dhcpIPPool.Id] = 0
+		}
+		for _, lease := range dhcpLeases.Leases {
+			expireTime, err := time.Parse(dhcpLeaseTimeLayout, lease.ExpireTime)
+			if err != nil {
+				level.Debug(dc.logger).Log("msg", "Unable to parse dhcp lease expire time", "id", dhcp.Id, "ip", lease.IpAddress, "err", err)
+				continue
+			}
+			if !expireTime.After(now) {
+				continue
+			}
+			dhcpLeaseMetric.ActiveLeases++
+			if expireTime.After(now.Add(dc.leaseExpiryWindow)) {
+				continue
+			}
+			ip := net.ParseIP(lease.IpAddress)
+			for _, dhcpIPPool := range dhcpIPPools {
+				for _, allocationRange := range dhcpIPPool.AllocationRanges {
+					if ipRangeContains(allocationRange, ip) {
+						dhcpLeaseMetric.IPPoolExpiringLeases[dhcpIPPool.Id]++
+						break
+					}
+				}
+			}
+		}
+		dhcpLeaseMetrics = append(dhcpLeaseMetrics, dhcpLeaseMetric)
+	}
+	return
+}
+
+func (dc *dhcpCollector) generateDHCPStaticBindingMetrics(dhcpServers []manager.LogicalDhcpServer) (dhcpStaticBindingMetrics []dhcpStaticBindingMetric) {
+	for _, dhcp := range dhcpServers {
+		dhcpStaticBindings, err := dc.dhcpClient.ListAllDHCPStaticBindings(dhcp.Id)
+		if err != nil {
+			level.Error(dc.logger).Log("msg", "Unable to list dhcp static bindings", "id", dhcp.Id, "err", err)
+			continue
+		}
+		dhcpStaticBindingMetric := dhcpStaticBindingMetric{
+			ID:             dhcp.Id,
+			Name:           dhcp.DisplayName,
+			StaticBindings: float64(len(dhcpStaticBindings)),
+		}
+		dhcpStaticBindingMetrics = append(dhcpStaticBindingMetrics, dhcpStaticBindingMetric)
+	}
+	return
+}
@@ -3,6 +3,7 @@ package collector
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,9 @@ type mockDHCPResponse struct {
 	Status      string
 	Error       error
 	Statistics  manager.DhcpStatistics
+	Leases      []manager.DhcpLeasePerIp
+	IPPools     []manager.DhcpIpPool
+	Bindings    []manager.DhcpStaticBinding
 }
 
 func (c *mockDHCPClient) ListAllDHCPServers() ([]manager.LogicalDhcpServer, error) {
@@ -52,6 +56,36 @@ func (c *mockDHCPClient) GetDHCPStatistic(dhcpID string) (manager.DhcpStatistics
 	return manager.DhcpStatistics{}, errors.New("dhcp not found")
 }
 
+func (c *mockDHCPClient) GetDHCPLeaseInfo(dhcpID string) (manager.DhcpLeases, error) {
+	for _, res := range c.responses {
+		if res.ID == dhcpID {
+			return manager.DhcpLeases{
+				DhcpServerId: dhcpID,
+				Leases:       res.Leases,
+			}, res.Error
+		}
+	}
+	return manager.DhcpLeases{}, errors.New("dhcp not found")
+}
+
+func (c *mockDHCPClient) ListAllDHCPIPPools(dhcpID string) ([]manager.DhcpIpPool, error) {
+	for _, res := range c.responses {
+		if res.ID == dhcpID {
+			return res.IPPools, res.Error
+		}
+	}
+	return nil, errors.New("dhcp not found")
+}
+
+func (c *mockDHCPClient) ListAllDHCPStaticBindings(dhcpID string) ([]manager.DhcpStaticBinding, error) {
+	for _, res := range c.responses {
+		if res.ID == dhcpID {
+			return res.Bindings, res.Error
+		}
+	}
+	return nil, errors.New("dhcp not found")
+}
+
 func buildDHCPStatusResponse(id string, status string, err error) mockDHCPResponse {
 	return mockDHCPResponse{
 		ID:          fakeDHCPServerID + "-" + id,
@@ -198,7 +232,7 @@ func TestDHCPCollector_GenerateDHCPStatisticMetrics(t *testing.T) {
 			dhcpServers = append(dhcpServers, dhcpServer)
 		}
 		logger := log.NewNopLogger()
-		dhcpCollector := newDHCPCollector(mockDHCPClient, logger)
+		dhcpCollector := newDHCPCollector(mockDHCPClient, logger, time.Hour, false)
 		dhcpMetrics := dhcpCollector.generateDHCPStatisticMetrics(dhcpServers)
 		assert.ElementsMatch(t, tc.expectedMetrics, dhcpMetrics, tc.description)
 	}
@@ -313,8 +347,111 @@ func TestDHCPCollector_GenerateDHCPStatusMetrics(t *testing.T) {
 			dhcpServers = append(dhcpServers, dhcpServer)
 		}
 		logger := log.NewNopLogger()
-		dhcpCollector := newDHCPCollector(mockDHCPClient, logger)
+		dhcpCollector := newDHCPCollector(mockDHCPClient, logger, time.Hour, false)
 		dhcpMetrics := dhcpCollector.generateDHCPStatusMetrics(dhcpServers)
 		assert.ElementsMatch(t, tc.expectedMetrics, dhcpMetrics, tc.description)
 	}
 }
+
+func buildDHCPLeaseResponse(id string, err error) mockDHCPResponse {
+	return mockDHCPResponse{
+		ID:          fakeDHCPServerID + "-" + id,
+		DisplayName: fakeDHCPServerDisplayName + "-" + id,
+		Error:       err,
+		Leases: []manager.DhcpLeasePerIp{
+			{IpAddress: "10.0.0.10", ExpireTime: "2020/06/01 10:30:00"},
+			{IpAddress: "10.0.0.11", ExpireTime: "2020/06/01 12:00:00"},
+			{IpAddress: "10.0.1.10", ExpireTime: "2020/06/01 10:15:00"},
+			{IpAddress: "10.0.0.12", ExpireTime: "2020/06/01 09:00:00"},
+			{IpAddress: "10.0.0.13", ExpireTime: "invalid"},
+		},
+		IPPools: []manager.DhcpIpPool{
+			{
+				Id: fakeDHCPPoolID + "-" + id,
+				AllocationRanges: []manager.IpPoolRange{
+					{Start: "10.0.0.1", End: "10.0.0.254"},
+					{Start: "10.0.0.1", End: "10.0.0.127"},
+				},
+			}, {
+				Id: fakeDHCPPoolID + "-" + id + "-empty",
+				AllocationRanges: []manager.IpPoolRange{
+					{Start: "10.0.2.1", End: "10.0.2.254"},
+				},
+			},
+		},
+		Bindings: []manager.DhcpStaticBinding{
+			{IpAddress: "10.0.0.2"},
+			{IpAddress: "10.0.0.3"},
+		},
+	}
+}
+
+func TestDHCPCollector_GenerateDHCPLeaseMetrics(t *testing.T) {
+	now := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		description     string
+		dhcpResponses   []mockDHCPResponse
+		expectedMetrics []dhcpLeaseMetric
+	}{
+		{
+			description:   "Should count active leases and leases expiring within window per ip pool",
+			dhcpResponses: []mockDHCPResponse{buildDHCPLeaseResponse("01", nil)},
+			expectedMetrics: []dhcpLeaseMetric{
+				{
+					ID:           fakeDHCPServerID + "-01",
+					Name:         fakeDHCPServerDisplayName + "-01",
+					ActiveLeases: 3,
+					IPPoolExpiringLeases: map[string]float64{
+						fakeDHCPPoolID + "-01":       1,
+						fakeDHCPPoolID + "-01-empty": 0,
+					},
+				},
+			},
+		}, {
+			description:     "Should skip dhcp server when lease info is unavailable",
+			dhcpResponses:   []mockDHCPResponse{buildDHCPLeaseResponse("01", errors.New("error getting lease info"))},
+			expectedMetrics: []dhcpLeaseMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockDHCPClient := &mockDHCPClient{
+			responses: tc.dhcpResponses,
+		}
+		var dhcpServers []manager.LogicalDhcpServer
+		for _, res := range tc.dhcpResponses {
+			dhcpServers = append(dhcpServers, manager.LogicalDhcpServer{
+				Id:          res.ID,
+				DisplayName: res.DisplayName,
+			})
+		}
+		logger := log.NewNopLogger()
+		dhcpCollector := newDHCPCollector(mockDHCPClient, logger, time.Hour, false)
+		dhcpMetrics := dhcpCollector.generateDHCPLeaseMetrics(dhcpServers, now)
+		assert.ElementsMatch(t, tc.expectedMetrics, dhcpMetrics, tc.description)
+	}
+}
+
+func TestDHCPCollector_GenerateDHCPStaticBindingMetrics(t *testing.T) {
+	mockDHCPClient := &mockDHCPClient{
+		responses: []mockDHCPResponse{
+			buildDHCPLeaseResponse("01", nil),
+		},
+	}
+	dhcpServers := []manager.LogicalDhcpServer{
+		{
+			Id:          fakeDHCPServerID + "-01",
+			DisplayName: fakeDHCPServerDisplayName + "-01",
+		},
+	}
+	expectedMetrics := []dhcpStaticBindingMetric{
+		{
+			ID:             fakeDHCPServerID + "-01",
+			Name:           fakeDHCPServerDisplayName + "-01",
+			StaticBindings: 2,
+		},
+	}
+	logger := log.NewNopLogger()
+	dhcpCollector := newDHCPCollector(mockDHCPClient, logger, time.Hour, true)
+	dhcpMetrics := dhcpCollector.generateDHCPStaticBindingMetrics(dhcpServers)
+	assert.ElementsMatch(t, expectedMetrics, dhcpMetrics)
+}
@@ -0,0 +1,179 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func init() {
+	registerCollector("edge_cluster", createEdgeClusterCollectorFactory)
+}
+
+type edgeClusterCollector struct {
+	edgeClusterClient client.EdgeClusterClient
+	logger            log.Logger
+
+	edgeClusterInfo                      *prometheus.Desc
+	edgeClusterMembers                   *prometheus.Desc
+	edgeClusterMemberStatus              *prometheus.Desc
+	edgeClusterMemberAllocatedServices   *prometheus.Desc
+	edgeClusterMemberAllocationPoolUsage *prometheus.Desc
+}
+
+type edgeClusterMetric struct {
+	ID             string
+	Name           string
+	DeploymentType string
+	MemberNodeType string
+	Members        []edgeClusterMemberMetric
+}
+
+type edgeClusterMemberMetric struct {
+	TransportNodeID     string
+	MemberIndex         string
+	StatusDetail        map[string]float64
+	AllocatedServices   map[string]float64
+	AllocationPoolUsage map[string]float64
+}
+
+func createEdgeClusterCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newEdgeClusterCollector(nsxtClient, logger)
+}
+
+func newEdgeClusterCollector(edgeClusterClient client.EdgeClusterClient, logger log.Logger) *edgeClusterCollector {
+	edgeClusterInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "edge_cluster", "info"),
+		"Info of edge cluster",
+		[]string{"id", "name", "deployment_type", "member_node_type"},
+		nil,
+	)
+	edgeClusterMembers := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "edge_cluster", "members"),
+		"Number of members in edge cluster",
+		[]string{"id", "name"},
+		nil,
+	)
+	edgeClusterMemberStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "edge_cluster", "member_status"),
+		"Status of edge cluster member",
+		[]string{"edge_cluster_id", "transport_node_id", "edge_member_index", "status"},
+		nil,
+	)
+	edgeClusterMemberAllocatedServices := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "edge_cluster", "member_allocated_services"),
+		"Number of services allocated on edge cluster member",
+		[]string{"edge_cluster_id", "transport_node_id", "edge_member_index", "service_type"},
+		nil,
+	)
+	edgeClusterMemberAllocationPoolUsage := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "edge_cluster", "member_allocation_pool_usage_percent"),
+		"Capacity usage of allocation pool on edge cluster member",
+		[]string{"edge_cluster_id", "transport_node_id", "edge_member_index", "allocation_pool_type"},
+		nil,
+	)
+	return &edgeClusterCollector{
+		edgeClusterClient: edgeClusterClient,
+		logger:            logger,
+
+		edgeClusterInfo:                      edgeClusterInfo,
+		edgeClusterMembers:                   edgeClusterMembers,
+		edgeClusterMemberStatus:              edgeClusterMemberStatus,
+		edgeClusterMemberAllocatedServices:   edgeClusterMemberAllocatedServices,
+		edgeClusterMemberAllocationPoolUsage: edgeClusterMemberAllocationPoolUsage,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *edgeClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.edgeClusterInfo
+	ch <- c.edgeClusterMembers
+	ch <- c.edgeClusterMemberStatus
+	ch <- c.edgeClusterMemberAllocatedServices
+	ch <- c.edgeClusterMemberAllocationPoolUsage
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *edgeClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	edgeClusters, err := c.edgeClusterClient.ListAllEdgeClusters()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list edge clusters", "err", err)
+		return
+	}
+	edgeClusterMetrics := c.generateEdgeClusterMetrics(edgeClusters)
+	for _, m := range edgeClusterMetrics {
+		ch <- prometheus.MustNewConstMetric(c.edgeClusterInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.DeploymentType, m.MemberNodeType)
+		ch <- prometheus.MustNewConstMetric(c.edgeClusterMembers, prometheus.GaugeValue, float64(len(m.Members)), m.ID, m.Name)
+		for _, member := range m.Members {
+			memberLabels := []string{m.ID, member.TransportNodeID, member.MemberIndex}
+			for status, value := range member.StatusDetail {
+				ch <- prometheus.MustNewConstMetric(c.edgeClusterMemberStatus, prometheus.GaugeValue, value, append(memberLabels, status)...)
+			}
+			for serviceType, value := range member.AllocatedServices {
+				ch <- prometheus.MustNewConstMetric(c.edgeClusterMemberAllocatedServices, prometheus.GaugeValue, value, append(memberLabels, serviceType)...)
+			}
+			for poolType, value := range member.AllocationPoolUsage {
+				ch <- prometheus.MustNewConstMetric(c.edgeClusterMemberAllocationPoolUsage, prometheus.GaugeValue, value, append(memberLabels, poolType)...)
+			}
+		}
+	}
+}
+
+func (c *edgeClusterCollector) generateEdgeClusterMetrics(edgeClusters []manager.EdgeCluster) (edgeClusterMetrics []edgeClusterMetric) {
+	for _, ec := range edgeClusters {
+		allocationStatus, err := c.edgeClusterClient.GetEdgeClusterAllocationStatus(ec.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get edge cluster allocation status", "id", ec.Id, "err", err)
+		}
+		memberAllocations := make(map[string]client.EdgeClusterMemberAllocationStatus)
+		for _, memberAllocation := range allocationStatus.Members {
+			memberAllocations[memberAllocation.TransportNodeID] = memberAllocation
+		}
+		edgeClusterMetric := edgeClusterMetric{
+			ID:             ec.Id,
+			Name:           ec.DisplayName,
+			DeploymentType: ec.DeploymentType,
+			MemberNodeType: ec.MemberNodeType,
+		}
+		for _, member := range ec.Members {
+			memberMetric := edgeClusterMemberMetric{
+				TransportNodeID:     member.TransportNodeId,
+				MemberIndex:         strconv.Itoa(int(member.MemberIndex)),
+				AllocatedServices:   map[string]float64{},
+				AllocationPoolUsage: map[string]float64{},
+			}
+			transportNodeStatus, err := c.edgeClusterClient.GetTransportNodeStatus(member.TransportNodeId)
+			if err != nil {
+				level.Error(c.logger).Log("msg", "Unable to get edge cluster member status", "id", member.TransportNodeId, "err", err)
+			} else {
+				memberMetric.StatusDetail = map[string]float64{}
+				for _, status := range transportNodePossibleStatus {
+					statusValue := 0.0
+					if status == strings.ToUpper(transportNodeStatus.Status) {
+						statusValue = 1.0
+					}
+					memberMetric.StatusDetail[status] = statusValue
+				}
+			}
+			if memberAllocation, ok := memberAllocations[member.TransportNodeId]; ok {
+				for _, service := range memberAllocation.AllocatedServices {
+					memberMetric.AllocatedServices[service.ServiceReference.TargetType]++
+				}
+				for _, pool := range memberAllocation.AllocationPools {
+					memberMetric.AllocationPoolUsage[pool.AllocationPoolType] = pool.UsagePercentage
+				}
+			}
+			edgeClusterMetric.Members = append(edgeClusterMetric.Members, memberMetric)
+		}
+		edgeClusterMetrics = append(edgeClusterMetrics, edgeClusterMetric)
+	}
+	return
+}
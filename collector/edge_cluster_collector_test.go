@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/common"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+type edgeClusterAllocationStatusResponse struct {
+	EdgeClusterID    string
+	AllocationStatus client.EdgeClusterAllocationStatus
+	Error            error
+}
+
+type edgeClusterMemberStatusResponse struct {
+	TransportNodeID string
+	Status          string
+	Error           error
+}
+
+type mockEdgeClusterClient struct {
+	allocationStatusResponses []edgeClusterAllocationStatusResponse
+	memberStatusResponses     []edgeClusterMemberStatusResponse
+}
+
+func (c *mockEdgeClusterClient) ListAllEdgeClusters() ([]manager.EdgeCluster, error) {
+	panic("unused function. Only used to satisfy EdgeClusterClient interface")
+}
+
+func (c *mockEdgeClusterClient) GetTransportNodeStatus(nodeID string) (manager.TransportNodeStatus, error) {
+	for _, res := range c.memberStatusResponses {
+		if res.TransportNodeID == nodeID {
+			return manager.TransportNodeStatus{
+				NodeUuid: nodeID,
+				Status:   res.Status,
+			}, res.Error
+		}
+	}
+	return manager.TransportNodeStatus{}, errors.New("transport node status not found")
+}
+
+func (c *mockEdgeClusterClient) GetEdgeClusterAllocationStatus(edgeClusterID string) (client.EdgeClusterAllocationStatus, error) {
+	for _, res := range c.allocationStatusResponses {
+		if res.EdgeClusterID == edgeClusterID {
+			return res.AllocationStatus, res.Error
+		}
+	}
+	return client.EdgeClusterAllocationStatus{}, errors.New("edge cluster allocation status not found")
+}
+
+func buildEdgeClusterMemberAllocationStatus(transportNodeID string, serviceTypes []string, usage float64) client.EdgeClusterMemberAllocationStatus {
+	var allocatedServices []client.AllocatedService
+	for _, serviceType := range serviceTypes {
+		allocatedServices = append(allocatedServices, client.AllocatedService{
+			HighAvailabilityStatus: "ACTIVE",
+			ServiceReference: common.ResourceReference{
+				TargetType: serviceType,
+			},
+		})
+	}
+	return client.EdgeClusterMemberAllocationStatus{
+		TransportNodeID: transportNodeID,
+		AllocationPools: []client.EdgeClusterMemberAllocationPool{
+			{
+				AllocationPoolType: "LoadBalancerAllocationPool",
+				UsagePercentage:    usage,
+			},
+		},
+		AllocatedServices: allocatedServices,
+	}
+}
+
+func buildExpectedEdgeClusterMemberStatusDetail(status string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, possibleStatus := range transportNodePossibleStatus {
+		statusDetail[possibleStatus] = 0.0
+	}
+	statusDetail[status] = 1.0
+	return statusDetail
+}
+
+func TestEdgeClusterCollector_GenerateEdgeClusterMetrics(t *testing.T) {
+	edgeClusters := []manager.EdgeCluster{
+		{
+			Id:             fakeEdgeClusterID("01"),
+			DisplayName:    "fake-edge-cluster-name-01",
+			DeploymentType: "VIRTUAL_MACHINE",
+			MemberNodeType: "EDGE_NODE",
+			Members: []manager.EdgeClusterMember{
+				{
+					MemberIndex:     0,
+					TransportNodeId: fakeTransportNodeID("01"),
+				}, {
+					MemberIndex:     1,
+					TransportNodeId: fakeTransportNodeID("02"),
+				},
+			},
+		},
+	}
+	testcases := []struct {
+		description               string
+		allocationStatusResponses []edgeClusterAllocationStatusResponse
+		memberStatusResponses     []edgeClusterMemberStatusResponse
+		expectedMetrics           []edgeClusterMetric
+	}{
+		{
+			description: "Should return member status and allocated services per edge member",
+			allocationStatusResponses: []edgeClusterAllocationStatusResponse{
+				{
+					EdgeClusterID: fakeEdgeClusterID("01"),
+					AllocationStatus: client.EdgeClusterAllocationStatus{
+						ID: fakeEdgeClusterID("01"),
+						Members: []client.EdgeClusterMemberAllocationStatus{
+							buildEdgeClusterMemberAllocationStatus(fakeTransportNodeID("01"), []string{"LogicalRouter", "LogicalRouter", "LbService"}, 25),
+							buildEdgeClusterMemberAllocationStatus(fakeTransportNodeID("02"), []string{"LogicalRouter"}, 0),
+						},
+					},
+				},
+			},
+			memberStatusResponses: []edgeClusterMemberStatusResponse{
+				{
+					TransportNodeID: fakeTransportNodeID("01"),
+					Status:          "UP",
+				}, {
+					TransportNodeID: fakeTransportNodeID("02"),
+					Status:          "degraded",
+				},
+			},
+			expectedMetrics: []edgeClusterMetric{
+				{
+					ID:             fakeEdgeClusterID("01"),
+					Name:           "fake-edge-cluster-name-01",
+					DeploymentType: "VIRTUAL_MACHINE",
+					MemberNodeType: "EDGE_NODE",
+					Members: []edgeClusterMemberMetric{
+						{
+							TransportNodeID:     fakeTransportNodeID("01"),
+							MemberIndex:         "0",
+							StatusDetail:        buildExpectedEdgeClusterMemberStatusDetail("UP"),
+							AllocatedServices:   map[string]float64{"LogicalRouter": 2, "LbService": 1},
+							AllocationPoolUsage: map[string]float64{"LoadBalancerAllocationPool": 25},
+						}, {
+							TransportNodeID:     fakeTransportNodeID("02"),
+							MemberIndex:         "1",
+							StatusDetail:        buildExpectedEdgeClusterMemberStatusDetail("DEGRADED"),
+							AllocatedServices:   map[string]float64{"LogicalRouter": 1},
+							AllocationPoolUsage: map[string]float64{"LoadBalancerAllocationPool": 0},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Should keep member status when allocation status and member status are unavailable",
+			allocationStatusResponses: []edgeClusterAllocationStatusResponse{
+				{
+					EdgeClusterID: fakeEdgeClusterID("01"),
+					Error:         errors.New("error getting allocation status"),
+				},
+			},
+			memberStatusResponses: []edgeClusterMemberStatusResponse{
+				{
+					TransportNodeID: fakeTransportNodeID("01"),
+					Status:          "DOWN",
+				}, {
+					TransportNodeID: fakeTransportNodeID("02"),
+					Error:           errors.New("error getting transport node status"),
+				},
+			},
+			expectedMetrics: []edgeClusterMetric{
+				{
+					ID:             fakeEdgeClusterID("01"),
+					Name:           "fake-edge-cluster-name-01",
+					DeploymentType: "VIRTUAL_MACHINE",
+					MemberNodeType: "EDGE_NODE",
+					Members: []edgeClusterMemberMetric{
+						{
+							TransportNodeID:     fakeTransportNodeID("01"),
+							MemberIndex:         "0",
+							StatusDetail:        buildExpectedEdgeClusterMemberStatusDetail("DOWN"),
+							AllocatedServices:   map[string]float64{},
+							AllocationPoolUsage: map[string]float64{},
+						}, {
+							TransportNodeID:     fakeTransportNodeID("02"),
+							MemberIndex:         "1",
+							AllocatedServices:   map[string]float64{},
+							AllocationPoolUsage: map[string]float64{},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockEdgeClusterClient{
+			allocationStatusResponses: tc.allocationStatusResponses,
+			memberStatusResponses:     tc.memberStatusResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newEdgeClusterCollector(mockClient, logger)
+		metrics := collector.generateEdgeClusterMetrics(edgeClusters)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
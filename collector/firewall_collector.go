@@ -1,15 +1,21 @@
 package collector
 
 import (
+	"strconv"
+
 	"nsxt_exporter/client"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
+var firewallDisabledRuleStatistics = kingpin.Flag("collector.firewall.disabled_rule_statistics", "Collect statistics of disabled firewall rules.").Default("false").Bool()
+
 func init() {
 	registerCollector("firewall", createFirewallCollectorFactory)
 }
@@ -18,24 +24,71 @@ type firewallCollector struct {
 	firewallClient client.FirewallClient
 	logger         log.Logger
 
-	totalPackets *prometheus.Desc
-	totalBytes   *prometheus.Desc
+	disabledRuleStatistics bool
+
+	ruleInfo          *prometheus.Desc
+	ruleAppliedTo     *prometheus.Desc
+	totalPackets      *prometheus.Desc
+	totalBytes        *prometheus.Desc
+	hitCount          *prometheus.Desc
+	sessionCount      *prometheus.Desc
+	maxSessionCount   *prometheus.Desc
+	totalSessionCount *prometheus.Desc
+	popularityIndex   *prometheus.Desc
+}
+
+type firewallRule struct {
+	Section  manager.FirewallSection
+	Rule     manager.FirewallRule
+	Position int
+}
+
+type firewallRuleInfoMetric struct {
+	SectionID   string
+	SectionName string
+	SectionType string
+	RuleID      string
+	RuleName    string
+	Action      string
+	Direction   string
+	IPProtocol  string
+	Logged      string
+	Disabled    string
+	Position    string
+	AppliedTos  []common.ResourceReference
 }
 
 type firewallStatisticMetric struct {
-	SectionID    string
-	RuleID       string
-	RuleName     string
-	TotalPackets float64
-	TotalBytes   float64
+	SectionID         string
+	RuleID            string
+	RuleName          string
+	TotalPackets      float64
+	TotalBytes        float64
+	HitCount          float64
+	SessionCount      float64
+	MaxSessionCount   float64
+	TotalSessionCount float64
+	PopularityIndex   float64
 }
 
 func createFirewallCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
 	nsxtClient := client.NewNSXTClient(apiClient, logger)
-	return newFirewallCollector(nsxtClient, logger)
+	return newFirewallCollector(nsxtClient, logger, *firewallDisabledRuleStatistics)
 }
 
-func newFirewallCollector(firewallClient client.FirewallClient, logger log.Logger) *firewallCollector {
+func newFirewallCollector(firewallClient client.FirewallClient, logger log.Logger, disabledRuleStatistics bool) *firewallCollector {
+	ruleInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "rule_info"),
+		"Info of firewall rule and its section",
+		[]string{"id", "name", "section_id", "section_name", "section_type", "action", "direction", "ip_protocol", "logged", "disabled", "position"},
+		nil,
+	)
+	ruleAppliedTo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "rule_applied_to_info"),
+		"Info of object the firewall rule is applied to",
+		[]string{"id", "name", "section_id", "target_id", "target_name", "target_type"},
+		nil,
+	)
 	totalPackets := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "firewall", "total_packets"),
 		"Total packets processed by the firewall rule",
@@ -48,18 +101,65 @@ func newFirewallCollector(firewallClient client.FirewallClient, logger log.Logge
 		[]string{"id", "name", "section_id"},
 		nil,
 	)
+	hitCount := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "hit_count"),
+		"Number of times the firewall rule was hit",
+		[]string{"id", "name", "section_id"},
+		nil,
+	)
+	sessionCount := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "session_count"),
+		"Number of active sessions handled by the firewall rule",
+		[]string{"id", "name", "section_id"},
+		nil,
+	)
+	maxSessionCount := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "max_session_count"),
+		"Maximum number of concurrent sessions handled by the firewall rule",
+		[]string{"id", "name", "section_id"},
+		nil,
+	)
+	totalSessionCount := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "total_session_count"),
+		"Total number of sessions handled by the firewall rule",
+		[]string{"id", "name", "section_id"},
+		nil,
+	)
+	popularityIndex := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "firewall", "popularity_index"),
+		"Popularity index of the firewall rule",
+		[]string{"id", "name", "section_id"},
+		nil,
+	)
 	return &firewallCollector{
 		firewallClient: firewallClient,
 		logger:         logger,
-		totalPackets:   totalPackets,
-		totalBytes:     totalBytes,
+
+		disabledRuleStatistics: disabledRuleStatistics,
+
+		ruleInfo:          ruleInfo,
+		ruleAppliedTo:     ruleAppliedTo,
+		totalPackets:      totalPackets,
+		totalBytes:        totalBytes,
+		hitCount:          hitCount,
+		sessionCount:      sessionCount,
+		maxSessionCount:   maxSessionCount,
+		totalSessionCount: totalSessionCount,
+		popularityIndex:   popularityIndex,
 	}
 }
 
 // Describe implements the prometheus.Collector interface.
 func (c *firewallCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ruleInfo
+	ch <- c.ruleAppliedTo
 	ch <- c.totalPackets
 	ch <- c.totalBytes
+	ch <- c.hitCount
+	ch <- c.sessionCount
+	ch <- c.maxSessionCount
+	ch <- c.totalSessionCount
+	ch <- c.popularityIndex
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -68,36 +168,95 @@ func (c *firewallCollector) Collect(ch chan<- prometheus.Metric) {
 	if err != nil {
 		level.Error(c.logger).Log("msg", "Unable to list firewall sections", "err", err)
 	}
-	firewallStatisticMetrics := c.generateFirewallStatisticMetrics(firewallSections)
+	firewallRules := c.listAllFirewallRules(firewallSections)
+	firewallRuleInfoMetrics := c.generateFirewallRuleInfoMetrics(firewallRules)
+	for _, m := range firewallRuleInfoMetrics {
+		ch <- prometheus.MustNewConstMetric(c.ruleInfo, prometheus.GaugeValue, 1.0, m.RuleID, m.RuleName, m.SectionID, m.SectionName, m.SectionType, m.Action, m.Direction, m.IPProtocol, m.Logged, m.Disabled, m.Position)
+		for _, appliedTo := range m.AppliedTos {
+			ch <- prometheus.MustNewConstMetric(c.ruleAppliedTo, prometheus.GaugeValue, 1.0, m.RuleID, m.RuleName, m.SectionID, appliedTo.TargetId, appliedTo.TargetDisplayName, appliedTo.TargetType)
+		}
+	}
+	firewallStatisticMetrics := c.generateFirewallStatisticMetrics(firewallRules)
 	for _, m := range firewallStatisticMetrics {
 		labels := []string{m.RuleID, m.RuleName, m.SectionID}
 		ch <- prometheus.MustNewConstMetric(c.totalPackets, prometheus.GaugeValue, m.TotalPackets, labels...)
 		ch <- prometheus.MustNewConstMetric(c.totalBytes, prometheus.GaugeValue, m.TotalBytes, labels...)
+		ch <- prometheus.MustNewConstMetric(c.hitCount, prometheus.GaugeValue, m.HitCount, labels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionCount, prometheus.GaugeValue, m.SessionCount, labels...)
+		ch <- prometheus.MustNewConstMetric(c.maxSessionCount, prometheus.GaugeValue, m.MaxSessionCount, labels...)
+		ch <- prometheus.MustNewConstMetric(c.totalSessionCount, prometheus.GaugeValue, m.TotalSessionCount, labels...)
+		ch <- prometheus.MustNewConstMetric(c.popularityIndex, prometheus.GaugeValue, m.PopularityIndex, labels...)
 	}
 }
 
-func (c *firewallCollector) generateFirewallStatisticMetrics(firewallSections []manager.FirewallSection) (firewallStatisticMetrics []firewallStatisticMetric) {
+// listAllFirewallRules lists rules of every firewall section along with their position in the section.
+func (c *firewallCollector) listAllFirewallRules(firewallSections []manager.FirewallSection) (firewallRules []firewallRule) {
 	for _, sec := range firewallSections {
 		rules, err := c.firewallClient.GetAllFirewallRules(sec.Id)
 		if err != nil {
 			level.Error(c.logger).Log("msg", "Unable to get firewall rules", "section", sec.Id, "err", err)
 			continue
 		}
-		for _, rule := range rules {
-			stats, err := c.firewallClient.GetFirewallStats(sec.Id, rule.Id)
-			if err != nil {
-				level.Error(c.logger).Log("msg", "Unable to get firewall statistic", "section", sec.Id, "rule", rule.Id, "err", err)
-				continue
-			}
-			firewallStatisticMetric := firewallStatisticMetric{
-				SectionID:    sec.Id,
-				RuleID:       rule.Id,
-				RuleName:     rule.DisplayName,
-				TotalPackets: float64(stats.PacketCount),
-				TotalBytes:   float64(stats.ByteCount),
-			}
-			firewallStatisticMetrics = append(firewallStatisticMetrics, firewallStatisticMetric)
+		for i, rule := range rules {
+			firewallRules = append(firewallRules, firewallRule{
+				Section:  sec,
+				Rule:     rule,
+				Position: i + 1,
+			})
+		}
+	}
+	return
+}
+
+func (c *firewallCollector) generateFirewallRuleInfoMetrics(firewallRules []firewallRule) (firewallRuleInfoMetrics []firewallRuleInfoMetric) {
+	for _, r := range firewallRules {
+		// Rules without applied-to objects are applied to the objects of their section.
+		appliedTos := r.Rule.AppliedTos
+		if len(appliedTos) == 0 {
+			appliedTos = r.Section.AppliedTos
+		}
+		firewallRuleInfoMetric := firewallRuleInfoMetric{
+			SectionID:   r.Section.Id,
+			SectionName: r.Section.DisplayName,
+			SectionType: r.Section.SectionType,
+			RuleID:      r.Rule.Id,
+			RuleName:    r.Rule.DisplayName,
+			Action:      r.Rule.Action,
+			Direction:   r.Rule.Direction,
+			IPProtocol:  r.Rule.IpProtocol,
+			Logged:      strconv.FormatBool(r.Rule.Logged),
+			Disabled:    strconv.FormatBool(r.Rule.Disabled),
+			Position:    strconv.Itoa(r.Position),
+			AppliedTos:  appliedTos,
+		}
+		firewallRuleInfoMetrics = append(firewallRuleInfoMetrics, firewallRuleInfoMetric)
+	}
+	return
+}
+
+func (c *firewallCollector) generateFirewallStatisticMetrics(firewallRules []firewallRule) (firewallStatisticMetrics []firewallStatisticMetric) {
+	for _, r := range firewallRules {
+		if r.Rule.Disabled && !c.disabledRuleStatistics {
+			continue
+		}
+		stats, err := c.firewallClient.GetFirewallStats(r.Section.Id, r.Rule.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get firewall statistic", "section", r.Section.Id, "rule", r.Rule.Id, "err", err)
+			continue
+		}
+		firewallStatisticMetric := firewallStatisticMetric{
+			SectionID:         r.Section.Id,
+			RuleID:            r.Rule.Id,
+			RuleName:          r.Rule.DisplayName,
+			TotalPackets:      float64(stats.PacketCount),
+			TotalBytes:        float64(stats.ByteCount),
+			HitCount:          float64(stats.HitCount),
+			SessionCount:      float64(stats.SessionCount),
+			MaxSessionCount:   float64(stats.MaxSessionCount),
+			TotalSessionCount: float64(stats.TotalSessionCount),
+			PopularityIndex:   float64(stats.PopularityIndex),
 		}
+		firewallStatisticMetrics = append(firewallStatisticMetrics, firewallStatisticMetric)
 	}
 	return
 }
@@ -5,17 +5,25 @@ import (
 	"fmt"
 	"testing"
 
+	"nsxt_exporter/client"
+
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
 )
 
 const (
-	fakeFirewallSectionID        = "fake-firewall-section-id"
-	fakeFirewallRuleID           = "fake-firewall-rule-id"
-	fakeFirewallRuleName         = "fake-firewall-rule-name"
-	fakeFirewallRuleTotalPackets = 1
-	fakeFirewallRuleTotalBytes   = 1
+	fakeFirewallSectionID             = "fake-firewall-section-id"
+	fakeFirewallRuleID                = "fake-firewall-rule-id"
+	fakeFirewallRuleName              = "fake-firewall-rule-name"
+	fakeFirewallRuleTotalPackets      = 1
+	fakeFirewallRuleTotalBytes        = 1
+	fakeFirewallRuleHitCount          = 2
+	fakeFirewallRuleSessionCount      = 3
+	fakeFirewallRuleMaxSessionCount   = 4
+	fakeFirewallRuleTotalSessionCount = 5
+	fakeFirewallRulePopularityIndex   = 6
 )
 
 type mockFirewallClient struct {
@@ -26,7 +34,7 @@ type mockFirewallClient struct {
 type mockFirewallResponse struct {
 	Section    manager.FirewallSection
 	Rules      []manager.FirewallRule
-	Statistics []manager.FirewallStats
+	Statistics []client.FirewallStats
 	Error      error
 }
 
@@ -43,18 +51,12 @@ func (c *mockFirewallClient) GetAllFirewallRules(sectionID string) ([]manager.Fi
 		if res.Section.Id != sectionID {
 			continue
 		}
-		for _, rule := range res.Rules {
-			firewallRule := manager.FirewallRule{
-				Id:          rule.Id,
-				DisplayName: rule.DisplayName,
-			}
-			firewallRules = append(firewallRules, firewallRule)
-		}
+		firewallRules = append(firewallRules, res.Rules...)
 	}
 	return firewallRules, nil
 }
 
-func (c *mockFirewallClient) GetFirewallStats(sectionID string, ruleID string) (manager.FirewallStats, error) {
+func (c *mockFirewallClient) GetFirewallStats(sectionID string, ruleID string) (client.FirewallStats, error) {
 	for _, res := range c.responses {
 		if res.Section.Id != sectionID {
 			continue
@@ -63,26 +65,31 @@ func (c *mockFirewallClient) GetFirewallStats(sectionID string, ruleID string) (
 			if rule.Id != ruleID {
 				continue
 			}
-			return manager.FirewallStats{
-				RuleId:      ruleID,
-				PacketCount: fakeFirewallRuleTotalPackets,
-				ByteCount:   fakeFirewallRuleTotalBytes,
+			return client.FirewallStats{
+				RuleID:            ruleID,
+				PacketCount:       fakeFirewallRuleTotalPackets,
+				ByteCount:         fakeFirewallRuleTotalBytes,
+				HitCount:          fakeFirewallRuleHitCount,
+				SessionCount:      fakeFirewallRuleSessionCount,
+				MaxSessionCount:   fakeFirewallRuleMaxSessionCount,
+				TotalSessionCount: fakeFirewallRuleTotalSessionCount,
+				PopularityIndex:   fakeFirewallRulePopularityIndex,
 			}, res.Error
 		}
 	}
-	return manager.FirewallStats{}, errors.New("error firewall rule not found")
+	return client.FirewallStats{}, errors.New("error firewall rule not found")
 }
 
 func buildFirewallResponse(sectionID string, ruleIDs []string, err error) mockFirewallResponse {
 	var firewallRules []manager.FirewallRule
-	var firewallStatistics []manager.FirewallStats
+	var firewallStatistics []client.FirewallStats
 	for _, ruleID := range ruleIDs {
 		firewallRules = append(firewallRules, manager.FirewallRule{
 			Id:          fmt.Sprintf("%s-%s", fakeFirewallRuleID, ruleID),
 			DisplayName: fmt.Sprintf("%s-%s", fakeFirewallRuleName, ruleID),
 		})
-		firewallStatistics = append(firewallStatistics, manager.FirewallStats{
-			RuleId:      fmt.Sprintf("%s-%s", fakeFirewallRuleID, ruleID),
+		firewallStatistics = append(firewallStatistics, client.FirewallStats{
+			RuleID:      fmt.Sprintf("%s-%s", fakeFirewallRuleID, ruleID),
 			PacketCount: fakeFirewallRuleTotalPackets,
 			ByteCount:   fakeFirewallRuleTotalBytes,
 		})
@@ -120,25 +127,40 @@ func TestFirewallCollector_GenerateFirewallStatisticMetrics(t *testing.T) {
 			},
 			expectedMetrics: []firewallStatisticMetric{
 				{
-					SectionID:    fmt.Sprintf("%s-1", fakeFirewallSectionID),
-					RuleID:       fmt.Sprintf("%s-1", fakeFirewallRuleID),
-					RuleName:     fmt.Sprintf("%s-1", fakeFirewallRuleName),
-					TotalPackets: fakeFirewallRuleTotalPackets,
-					TotalBytes:   fakeFirewallRuleTotalBytes,
+					SectionID:         fmt.Sprintf("%s-1", fakeFirewallSectionID),
+					RuleID:            fmt.Sprintf("%s-1", fakeFirewallRuleID),
+					RuleName:          fmt.Sprintf("%s-1", fakeFirewallRuleName),
+					TotalPackets:      fakeFirewallRuleTotalPackets,
+					TotalBytes:        fakeFirewallRuleTotalBytes,
+					HitCount:          fakeFirewallRuleHitCount,
+					SessionCount:      fakeFirewallRuleSessionCount,
+					MaxSessionCount:   fakeFirewallRuleMaxSessionCount,
+					TotalSessionCount: fakeFirewallRuleTotalSessionCount,
+					PopularityIndex:   fakeFirewallRulePopularityIndex,
 				},
 				{
-					SectionID:    fmt.Sprintf("%s-1", fakeFirewallSectionID),
-					RuleID:       fmt.Sprintf("%s-2", fakeFirewallRuleID),
-					RuleName:     fmt.Sprintf("%s-2", fakeFirewallRuleName),
-					TotalPackets: fakeFirewallRuleTotalPackets,
-					TotalBytes:   fakeFirewallRuleTotalBytes,
+					SectionID:         fmt.Sprintf("%s-1", fakeFirewallSectionID),
+					RuleID:            fmt.Sprintf("%s-2", fakeFirewallRuleID),
+					RuleName:          fmt.Sprintf("%s-2", fakeFirewallRuleName),
+					TotalPackets:      fakeFirewallRuleTotalPackets,
+					TotalBytes:        fakeFirewallRuleTotalBytes,
+					HitCount:          fakeFirewallRuleHitCount,
+					SessionCount:      fakeFirewallRuleSessionCount,
+					MaxSessionCount:   fakeFirewallRuleMaxSessionCount,
+					TotalSessionCount: fakeFirewallRuleTotalSessionCount,
+					PopularityIndex:   fakeFirewallRulePopularityIndex,
 				},
 				{
-					SectionID:    fmt.Sprintf("%s-2", fakeFirewallSectionID),
-					RuleID:       fmt.Sprintf("%s-3", fakeFirewallRuleID),
-					RuleName:     fmt.Sprintf("%s-3", fakeFirewallRuleName),
-					TotalPackets: fakeFirewallRuleTotalPackets,
-					TotalBytes:   fakeFirewallRuleTotalBytes,
+					SectionID:         fmt.Sprintf("%s-2", fakeFirewallSectionID),
+					RuleID:            fmt.Sprintf("%s-3", fakeFirewallRuleID),
+					RuleName:          fmt.Sprintf("%s-3", fakeFirewallRuleName),
+					TotalPackets:      fakeFirewallRuleTotalPackets,
+					TotalBytes:        fakeFirewallRuleTotalBytes,
+					HitCount:          fakeFirewallRuleHitCount,
+					SessionCount:      fakeFirewallRuleSessionCount,
+					MaxSessionCount:   fakeFirewallRuleMaxSessionCount,
+					TotalSessionCount: fakeFirewallRuleTotalSessionCount,
+					PopularityIndex:   fakeFirewallRulePopularityIndex,
 				},
 			},
 		},
@@ -159,9 +181,112 @@ func TestFirewallCollector_GenerateFirewallStatisticMetrics(t *testing.T) {
 			responses: tc.firewallResponses,
 		}
 		logger := log.NewNopLogger()
-		firewallCollector := newFirewallCollector(mockFirewallClient, logger)
+		firewallCollector := newFirewallCollector(mockFirewallClient, logger, false)
 		firewallSections := buildFirewallSections(tc.firewallResponses)
-		metrics := firewallCollector.generateFirewallStatisticMetrics(firewallSections)
+		firewallRules := firewallCollector.listAllFirewallRules(firewallSections)
+		metrics := firewallCollector.generateFirewallStatisticMetrics(firewallRules)
 		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
 	}
 }
+
+func TestFirewallCollector_GenerateFirewallStatisticMetricsForDisabledRules(t *testing.T) {
+	firewallResponse := buildFirewallResponse("1", []string{"1", "2"}, nil)
+	firewallResponse.Rules[1].Disabled = true
+	testcases := []struct {
+		description            string
+		disabledRuleStatistics bool
+		expectedRuleIDs        []string
+	}{
+		{
+			description:     "Should skip statistics of disabled rules by default",
+			expectedRuleIDs: []string{fakeFirewallRuleID + "-1"},
+		}, {
+			description:            "Should return statistics of disabled rules when enabled",
+			disabledRuleStatistics: true,
+			expectedRuleIDs:        []string{fakeFirewallRuleID + "-1", fakeFirewallRuleID + "-2"},
+		},
+	}
+	for _, tc := range testcases {
+		mockFirewallClient := &mockFirewallClient{
+			responses: []mockFirewallResponse{firewallResponse},
+		}
+		logger := log.NewNopLogger()
+		firewallCollector := newFirewallCollector(mockFirewallClient, logger, tc.disabledRuleStatistics)
+		firewallRules := firewallCollector.listAllFirewallRules([]manager.FirewallSection{firewallResponse.Section})
+		var ruleIDs []string
+		for _, m := range firewallCollector.generateFirewallStatisticMetrics(firewallRules) {
+			ruleIDs = append(ruleIDs, m.RuleID)
+		}
+		assert.ElementsMatch(t, tc.expectedRuleIDs, ruleIDs, tc.description)
+	}
+}
+
+func TestFirewallCollector_GenerateFirewallRuleInfoMetrics(t *testing.T) {
+	sectionAppliedTo := common.ResourceReference{TargetId: "fake-nsgroup-id", TargetDisplayName: "fake-nsgroup-name", TargetType: "NSGroup"}
+	ruleAppliedTo := common.ResourceReference{TargetId: "fake-logical-switch-id", TargetDisplayName: "fake-logical-switch-name", TargetType: "LogicalSwitch"}
+	section := manager.FirewallSection{
+		Id:          fakeFirewallSectionID + "-1",
+		DisplayName: "fake-firewall-section-name-1",
+		SectionType: "LAYER3",
+		AppliedTos:  []common.ResourceReference{sectionAppliedTo},
+	}
+	mockFirewallClient := &mockFirewallClient{
+		responses: []mockFirewallResponse{
+			{
+				Section: section,
+				Rules: []manager.FirewallRule{
+					{
+						Id:          fakeFirewallRuleID + "-1",
+						DisplayName: fakeFirewallRuleName + "-1",
+						Action:      "ALLOW",
+						Direction:   "IN_OUT",
+						IpProtocol:  "IPV4_IPV6",
+						Logged:      true,
+						AppliedTos:  []common.ResourceReference{ruleAppliedTo},
+					}, {
+						Id:          fakeFirewallRuleID + "-2",
+						DisplayName: fakeFirewallRuleName + "-2",
+						Action:      "DROP",
+						Direction:   "IN",
+						IpProtocol:  "IPV4",
+						Disabled:    true,
+					},
+				},
+			},
+		},
+	}
+	expectedMetrics := []firewallRuleInfoMetric{
+		{
+			SectionID:   fakeFirewallSectionID + "-1",
+			SectionName: "fake-firewall-section-name-1",
+			SectionType: "LAYER3",
+			RuleID:      fakeFirewallRuleID + "-1",
+			RuleName:    fakeFirewallRuleName + "-1",
+			Action:      "ALLOW",
+			Direction:   "IN_OUT",
+			IPProtocol:  "IPV4_IPV6",
+			Logged:      "true",
+			Disabled:    "false",
+			Position:    "1",
+			AppliedTos:  []common.ResourceReference{ruleAppliedTo},
+		}, {
+			SectionID:   fakeFirewallSectionID + "-1",
+			SectionName: "fake-firewall-section-name-1",
+			SectionType: "LAYER3",
+			RuleID:      fakeFirewallRuleID + "-2",
+			RuleName:    fakeFirewallRuleName + "-2",
+			Action:      "DROP",
+			Direction:   "IN",
+			IPProtocol:  "IPV4",
+			Logged:      "false",
+			Disabled:    "true",
+			Position:    "2",
+			AppliedTos:  []common.ResourceReference{sectionAppliedTo},
+		},
+	}
+	logger := log.NewNopLogger()
+	firewallCollector := newFirewallCollector(mockFirewallClient, logger, false)
+	firewallRules := firewallCollector.listAllFirewallRules([]manager.FirewallSection{section})
+	metrics := firewallCollector.generateFirewallRuleInfoMetrics(firewallRules)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
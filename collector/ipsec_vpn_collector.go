@@ -0,0 +1,351 @@
+package collector
+
+import (
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+)
+
+var ipsecVPNSessionPossibleStatus = [...]string{"UP", "DOWN", "DEGRADED"}
+var ipsecVPNIKEPossibleStatus = [...]string{"UP", "DOWN", "NEGOTIATING"}
+var ipsecVPNTunnelPossibleStatus = [...]string{"UP", "DOWN"}
+
+func init() {
+	registerCollector("ipsec_vpn", createIPSecVPNCollectorFactory)
+}
+
+type ipsecVPNCollector struct {
+	ipsecVPNClient client.IPSecVPNClient
+	logger         log.Logger
+
+	sessionStatus            *prometheus.Desc
+	sessionIKEStatus         *prometheus.Desc
+	sessionTunnels           *prometheus.Desc
+	sessionFailedTunnels     *prometheus.Desc
+	sessionBytesIn           *prometheus.Desc
+	sessionBytesOut          *prometheus.Desc
+	sessionPacketsIn         *prometheus.Desc
+	sessionPacketsOut        *prometheus.Desc
+	sessionDroppedPacketsIn  *prometheus.Desc
+	sessionDroppedPacketsOut *prometheus.Desc
+	tunnelStatus             *prometheus.Desc
+	tunnelBytesIn            *prometheus.Desc
+	tunnelBytesOut           *prometheus.Desc
+	tunnelPacketsIn          *prometheus.Desc
+	tunnelPacketsOut         *prometheus.Desc
+	tunnelDroppedPacketsIn   *prometheus.Desc
+	tunnelDroppedPacketsOut  *prometheus.Desc
+}
+
+type ipsecVPNSessionMetric struct {
+	ID                string
+	Name              string
+	LocalEndpoint     string
+	PeerAddress       string
+	StatusDetail      map[string]float64
+	IKEStatusDetail   map[string]float64
+	IKEFailReason     string
+	Tunnels           float64
+	FailedTunnels     float64
+	BytesIn           float64
+	BytesOut          float64
+	PacketsIn         float64
+	PacketsOut        float64
+	DroppedPacketsIn  float64
+	DroppedPacketsOut float64
+	TunnelMetrics     []ipsecVPNTunnelMetric
+}
+
+type ipsecVPNTunnelMetric struct {
+	LocalSubnet       string
+	PeerSubnet        string
+	DownReason        string
+	StatusDetail      map[string]float64
+	BytesIn           float64
+	BytesOut          float64
+	PacketsIn         float64
+	PacketsOut        float64
+	DroppedPacketsIn  float64
+	DroppedPacketsOut float64
+}
+
+func createIPSecVPNCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newIPSecVPNCollector(nsxtClient, logger)
+}
+
+func newIPSecVPNCollector(ipsecVPNClient client.IPSecVPNClient, logger log.Logger) *ipsecVPNCollector {
+	sessionLabels := []string{"session_id", "session_name", "local_endpoint", "peer_address"}
+	tunnelLabels := append(sessionLabels, "local_subnet", "peer_subnet")
+	sessionStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_status"),
+		"Status of IPSec VPN session",
+		append(sessionLabels, "status"),
+		nil,
+	)
+	sessionIKEStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_ike_status"),
+		"IKE negotiation status of IPSec VPN session",
+		append(sessionLabels, "status", "fail_reason"),
+		nil,
+	)
+	sessionTunnels := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_tunnels"),
+		"Total number of tunnels of IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionFailedTunnels := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_failed_tunnels"),
+		"Number of failed tunnels of IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionBytesIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_bytes_in"),
+		"Total bytes received on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionBytesOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_bytes_out"),
+		"Total bytes sent on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_packets_in"),
+		"Total packets received on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_packets_out"),
+		"Total packets sent on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionDroppedPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_dropped_packets_in"),
+		"Total received packets dropped on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	sessionDroppedPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "session_dropped_packets_out"),
+		"Total sent packets dropped on IPSec VPN session",
+		sessionLabels,
+		nil,
+	)
+	tunnelStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_status"),
+		"Status of IPSec VPN tunnel",
+		append(tunnelLabels, "status", "down_reason"),
+		nil,
+	)
+	tunnelBytesIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_bytes_in"),
+		"Total bytes received on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	tunnelBytesOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_bytes_out"),
+		"Total bytes sent on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	tunnelPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_packets_in"),
+		"Total packets received on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	tunnelPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_packets_out"),
+		"Total packets sent on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	tunnelDroppedPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_dropped_packets_in"),
+		"Total received packets dropped on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	tunnelDroppedPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ipsec_vpn", "tunnel_dropped_packets_out"),
+		"Total sent packets dropped on IPSec VPN tunnel",
+		tunnelLabels,
+		nil,
+	)
+	return &ipsecVPNCollector{
+		ipsecVPNClient: ipsecVPNClient,
+		logger:         logger,
+
+		sessionStatus:            sessionStatus,
+		sessionIKEStatus:         sessionIKEStatus,
+		sessionTunnels:           sessionTunnels,
+		sessionFailedTunnels:     sessionFailedTunnels,
+		sessionBytesIn:           sessionBytesIn,
+		sessionBytesOut:          sessionBytesOut,
+		sessionPacketsIn:         sessionPacketsIn,
+		sessionPacketsOut:        sessionPacketsOut,
+		sessionDroppedPacketsIn:  sessionDroppedPacketsIn,
+		sessionDroppedPacketsOut: sessionDroppedPacketsOut,
+		tunnelStatus:             tunnelStatus,
+		tunnelBytesIn:            tunnelBytesIn,
+		tunnelBytesOut:           tunnelBytesOut,
+		tunnelPacketsIn:          tunnelPacketsIn,
+		tunnelPacketsOut:         tunnelPacketsOut,
+		tunnelDroppedPacketsIn:   tunnelDroppedPacketsIn,
+		tunnelDroppedPacketsOut:  tunnelDroppedPacketsOut,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *ipsecVPNCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sessionStatus
+	ch <- c.sessionIKEStatus
+	ch <- c.sessionTunnels
+	ch <- c.sessionFailedTunnels
+	ch <- c.sessionBytesIn
+	ch <- c.sessionBytesOut
+	ch <- c.sessionPacketsIn
+	ch <- c.sessionPacketsOut
+	ch <- c.sessionDroppedPacketsIn
+	ch <- c.sessionDroppedPacketsOut
+	ch <- c.tunnelStatus
+	ch <- c.tunnelBytesIn
+	ch <- c.tunnelBytesOut
+	ch <- c.tunnelPacketsIn
+	ch <- c.tunnelPacketsOut
+	ch <- c.tunnelDroppedPacketsIn
+	ch <- c.tunnelDroppedPacketsOut
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *ipsecVPNCollector) Collect(ch chan<- prometheus.Metric) {
+	sessions, err := c.ipsecVPNClient.ListAllIPSecVPNSessions()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IPSec VPN sessions", "err", err)
+		return
+	}
+	localEndpoints, err := c.ipsecVPNClient.ListAllIPSecVPNLocalEndpoints()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IPSec VPN local endpoints", "err", err)
+	}
+	peerEndpoints, err := c.ipsecVPNClient.ListAllIPSecVPNPeerEndpoints()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IPSec VPN peer endpoints", "err", err)
+	}
+	sessionMetrics := c.generateIPSecVPNSessionMetrics(sessions, localEndpoints, peerEndpoints)
+	for _, m := range sessionMetrics {
+		sessionLabels := []string{m.ID, m.Name, m.LocalEndpoint, m.PeerAddress}
+		for status, value := range m.StatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.sessionStatus, prometheus.GaugeValue, value, append(sessionLabels, status)...)
+		}
+		for status, value := range m.IKEStatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.sessionIKEStatus, prometheus.GaugeValue, value, append(sessionLabels, status, m.IKEFailReason)...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.sessionTunnels, prometheus.GaugeValue, m.Tunnels, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionFailedTunnels, prometheus.GaugeValue, m.FailedTunnels, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionBytesIn, prometheus.GaugeValue, m.BytesIn, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionBytesOut, prometheus.GaugeValue, m.BytesOut, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionPacketsIn, prometheus.GaugeValue, m.PacketsIn, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionPacketsOut, prometheus.GaugeValue, m.PacketsOut, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionDroppedPacketsIn, prometheus.GaugeValue, m.DroppedPacketsIn, sessionLabels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionDroppedPacketsOut, prometheus.GaugeValue, m.DroppedPacketsOut, sessionLabels...)
+		for _, tunnel := range m.TunnelMetrics {
+			tunnelLabels := append(sessionLabels, tunnel.LocalSubnet, tunnel.PeerSubnet)
+			for status, value := range tunnel.StatusDetail {
+				ch <- prometheus.MustNewConstMetric(c.tunnelStatus, prometheus.GaugeValue, value, append(tunnelLabels, status, tunnel.DownReason)...)
+			}
+			ch <- prometheus.MustNewConstMetric(c.tunnelBytesIn, prometheus.GaugeValue, tunnel.BytesIn, tunnelLabels...)
+			ch <- prometheus.MustNewConstMetric(c.tunnelBytesOut, prometheus.GaugeValue, tunnel.BytesOut, tunnelLabels...)
+			ch <- prometheus.MustNewConstMetric(c.tunnelPacketsIn, prometheus.GaugeValue, tunnel.PacketsIn, tunnelLabels...)
+			ch <- prometheus.MustNewConstMetric(c.tunnelPacketsOut, prometheus.GaugeValue, tunnel.PacketsOut, tunnelLabels...)
+			ch <- prometheus.MustNewConstMetric(c.tunnelDroppedPacketsIn, prometheus.GaugeValue, tunnel.DroppedPacketsIn, tunnelLabels...)
+			ch <- prometheus.MustNewConstMetric(c.tunnelDroppedPacketsOut, prometheus.GaugeValue, tunnel.DroppedPacketsOut, tunnelLabels...)
+		}
+	}
+}
+
+func (c *ipsecVPNCollector) generateIPSecVPNSessionMetrics(sessions []client.IPSecVPNSession, localEndpoints []client.IPSecVPNLocalEndpoint, peerEndpoints []client.IPSecVPNPeerEndpoint) (sessionMetrics []ipsecVPNSessionMetric) {
+	localAddresses := make(map[string]string)
+	for _, localEndpoint := range localEndpoints {
+		localAddresses[localEndpoint.ID] = localEndpoint.LocalAddress
+	}
+	peerAddresses := make(map[string]string)
+	for _, peerEndpoint := range peerEndpoints {
+		peerAddresses[peerEndpoint.ID] = peerEndpoint.PeerAddress
+	}
+	for _, session := range sessions {
+		sessionStatus, err := c.ipsecVPNClient.GetIPSecVPNSessionStatus(session.ID)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get IPSec VPN session status", "id", session.ID, "err", err)
+			continue
+		}
+		sessionMetric := ipsecVPNSessionMetric{
+			ID:            session.ID,
+			Name:          session.DisplayName,
+			LocalEndpoint: localAddresses[session.LocalEndpointID],
+			PeerAddress:   peerAddresses[session.PeerEndpointID],
+			StatusDetail:  c.constructStatusDetail(ipsecVPNSessionPossibleStatus[:], sessionStatus.SessionStatus),
+			Tunnels:       float64(sessionStatus.TotalTunnels),
+			FailedTunnels: float64(sessionStatus.FailedTunnels),
+		}
+		if sessionStatus.IkeStatus != nil {
+			sessionMetric.IKEStatusDetail = c.constructStatusDetail(ipsecVPNIKEPossibleStatus[:], sessionStatus.IkeStatus.IkeSessionState)
+			sessionMetric.IKEFailReason = sessionStatus.IkeStatus.FailReason
+		}
+		if counters := sessionStatus.TrafficCounters; counters != nil {
+			sessionMetric.BytesIn = float64(counters.BytesIn)
+			sessionMetric.BytesOut = float64(counters.BytesOut)
+			sessionMetric.PacketsIn = float64(counters.PacketsIn)
+			sessionMetric.PacketsOut = float64(counters.PacketsOut)
+			sessionMetric.DroppedPacketsIn = float64(counters.DroppedPacketsIn)
+			sessionMetric.DroppedPacketsOut = float64(counters.DroppedPacketsOut)
+		}
+		sessionStatistics, err := c.ipsecVPNClient.GetIPSecVPNSessionStatistics(session.ID)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get IPSec VPN session statistics", "id", session.ID, "err", err)
+		}
+		for _, policyStatistics := range sessionStatistics.PolicyStatistics {
+			for _, tunnelStatistics := range policyStatistics.TunnelStatistics {
+				tunnelMetric := ipsecVPNTunnelMetric{
+					LocalSubnet:       tunnelStatistics.LocalSubnet,
+					PeerSubnet:        tunnelStatistics.PeerSubnet,
+					DownReason:        tunnelStatistics.TunnelDownReason,
+					StatusDetail:      c.constructStatusDetail(ipsecVPNTunnelPossibleStatus[:], tunnelStatistics.TunnelStatus),
+					BytesIn:           float64(tunnelStatistics.BytesIn),
+					BytesOut:          float64(tunnelStatistics.BytesOut),
+					PacketsIn:         float64(tunnelStatistics.PacketsIn),
+					PacketsOut:        float64(tunnelStatistics.PacketsOut),
+					DroppedPacketsIn:  float64(tunnelStatistics.DroppedPacketsIn),
+					DroppedPacketsOut: float64(tunnelStatistics.DroppedPacketsOut),
+				}
+				sessionMetric.TunnelMetrics = append(sessionMetric.TunnelMetrics, tunnelMetric)
+			}
+		}
+		sessionMetrics = append(sessionMetrics, sessionMetric)
+	}
+	return
+}
+
+func (c *ipsecVPNCollector) constructStatusDetail(possibleStatus []string, currentStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range possibleStatus {
+		statusValue := 0.0
+		if status == strings.ToUpper(currentStatus) {
+			statusValue = 1.0
+		}
+		statusDetail[status] = statusValue
+	}
+	return statusDetail
+}
@@ -0,0 +1,243 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeIPSecVPNLocalEndpointID = "fake-local-endpoint-id"
+	fakeIPSecVPNLocalAddress    = "192.0.2.1"
+	fakeIPSecVPNPeerEndpointID  = "fake-peer-endpoint-id"
+	fakeIPSecVPNPeerAddress     = "198.51.100.1"
+)
+
+func fakeIPSecVPNSessionID(id string) string {
+	return "fake-ipsec-vpn-session-id-" + id
+}
+
+func fakeIPSecVPNSessionName(id string) string {
+	return "fake-ipsec-vpn-session-name-" + id
+}
+
+type ipsecVPNSessionStatusResponse struct {
+	SessionID string
+	Status    client.IPSecVPNSessionStatus
+	Error     error
+}
+
+type ipsecVPNSessionStatisticsResponse struct {
+	SessionID  string
+	Statistics client.IPSecVPNSessionStatistics
+	Error      error
+}
+
+type mockIPSecVPNClient struct {
+	sessionStatusResponses     []ipsecVPNSessionStatusResponse
+	sessionStatisticsResponses []ipsecVPNSessionStatisticsResponse
+}
+
+func (c *mockIPSecVPNClient) ListAllIPSecVPNSessions() ([]client.IPSecVPNSession, error) {
+	panic("unused function. Only used to satisfy IPSecVPNClient interface")
+}
+
+func (c *mockIPSecVPNClient) ListAllIPSecVPNLocalEndpoints() ([]client.IPSecVPNLocalEndpoint, error) {
+	panic("unused function. Only used to satisfy IPSecVPNClient interface")
+}
+
+func (c *mockIPSecVPNClient) ListAllIPSecVPNPeerEndpoints() ([]client.IPSecVPNPeerEndpoint, error) {
+	panic("unused function. Only used to satisfy IPSecVPNClient interface")
+}
+
+func (c *mockIPSecVPNClient) GetIPSecVPNSessionStatus(sessionID string) (client.IPSecVPNSessionStatus, error) {
+	for _, res := range c.sessionStatusResponses {
+		if res.SessionID == sessionID {
+			return res.Status, res.Error
+		}
+	}
+	return client.IPSecVPNSessionStatus{}, errors.New("ipsec vpn session status not found")
+}
+
+func (c *mockIPSecVPNClient) GetIPSecVPNSessionStatistics(sessionID string) (client.IPSecVPNSessionStatistics, error) {
+	for _, res := range c.sessionStatisticsResponses {
+		if res.SessionID == sessionID {
+			return res.Statistics, res.Error
+		}
+	}
+	return client.IPSecVPNSessionStatistics{}, errors.New("ipsec vpn session statistics not found")
+}
+
+func buildExpectedIPSecVPNStatusDetail(possibleStatus []string, nonZeroStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range possibleStatus {
+		statusDetail[status] = 0.0
+	}
+	statusDetail[nonZeroStatus] = 1.0
+	return statusDetail
+}
+
+func TestIPSecVPNCollector_GenerateIPSecVPNSessionMetrics(t *testing.T) {
+	sessions := []client.IPSecVPNSession{
+		{
+			ID:              fakeIPSecVPNSessionID("01"),
+			DisplayName:     fakeIPSecVPNSessionName("01"),
+			LocalEndpointID: fakeIPSecVPNLocalEndpointID,
+			PeerEndpointID:  fakeIPSecVPNPeerEndpointID,
+		}, {
+			ID:              fakeIPSecVPNSessionID("02"),
+			DisplayName:     fakeIPSecVPNSessionName("02"),
+			LocalEndpointID: fakeIPSecVPNLocalEndpointID,
+			PeerEndpointID:  "unknown-peer-endpoint-id",
+		},
+	}
+	localEndpoints := []client.IPSecVPNLocalEndpoint{
+		{ID: fakeIPSecVPNLocalEndpointID, LocalAddress: fakeIPSecVPNLocalAddress},
+	}
+	peerEndpoints := []client.IPSecVPNPeerEndpoint{
+		{ID: fakeIPSecVPNPeerEndpointID, PeerAddress: fakeIPSecVPNPeerAddress},
+	}
+	testcases := []struct {
+		description                string
+		sessionStatusResponses     []ipsecVPNSessionStatusResponse
+		sessionStatisticsResponses []ipsecVPNSessionStatisticsResponse
+		expectedMetrics            []ipsecVPNSessionMetric
+	}{
+		{
+			description: "Should return session and tunnel metrics",
+			sessionStatusResponses: []ipsecVPNSessionStatusResponse{
+				{
+					SessionID: fakeIPSecVPNSessionID("01"),
+					Status: client.IPSecVPNSessionStatus{
+						SessionStatus: "DEGRADED",
+						TotalTunnels:  2,
+						FailedTunnels: 1,
+						IkeStatus: &client.IPSecVPNIKESessionStatus{
+							IkeSessionState: "UP",
+						},
+						TrafficCounters: &client.IPSecVPNTrafficCounters{
+							BytesIn:           1024,
+							BytesOut:          2048,
+							PacketsIn:         10,
+							PacketsOut:        20,
+							DroppedPacketsIn:  1,
+							DroppedPacketsOut: 2,
+						},
+					},
+				}, {
+					SessionID: fakeIPSecVPNSessionID("02"),
+					Status: client.IPSecVPNSessionStatus{
+						SessionStatus: "down",
+						TotalTunnels:  1,
+						FailedTunnels: 1,
+						IkeStatus: &client.IPSecVPNIKESessionStatus{
+							IkeSessionState: "DOWN",
+							FailReason:      "Peer not responding",
+						},
+					},
+				},
+			},
+			sessionStatisticsResponses: []ipsecVPNSessionStatisticsResponse{
+				{
+					SessionID: fakeIPSecVPNSessionID("01"),
+					Statistics: client.IPSecVPNSessionStatistics{
+						PolicyStatistics: []client.IPSecVPNPolicyTrafficStatistics{
+							{
+								TunnelStatistics: []client.IPSecVPNTunnelTrafficStatistics{
+									{
+										LocalSubnet:       "10.0.0.0/24",
+										PeerSubnet:        "10.1.0.0/24",
+										TunnelStatus:      "UP",
+										BytesIn:           1024,
+										BytesOut:          2048,
+										PacketsIn:         10,
+										PacketsOut:        20,
+										DroppedPacketsIn:  1,
+										DroppedPacketsOut: 2,
+									}, {
+										LocalSubnet:      "10.0.0.0/24",
+										PeerSubnet:       "10.2.0.0/24",
+										TunnelStatus:     "DOWN",
+										TunnelDownReason: "No proposal chosen",
+									},
+								},
+							},
+						},
+					},
+				}, {
+					SessionID: fakeIPSecVPNSessionID("02"),
+					Error:     errors.New("error getting ipsec vpn session statistics"),
+				},
+			},
+			expectedMetrics: []ipsecVPNSessionMetric{
+				{
+					ID:                fakeIPSecVPNSessionID("01"),
+					Name:              fakeIPSecVPNSessionName("01"),
+					LocalEndpoint:     fakeIPSecVPNLocalAddress,
+					PeerAddress:       fakeIPSecVPNPeerAddress,
+					StatusDetail:      buildExpectedIPSecVPNStatusDetail(ipsecVPNSessionPossibleStatus[:], "DEGRADED"),
+					IKEStatusDetail:   buildExpectedIPSecVPNStatusDetail(ipsecVPNIKEPossibleStatus[:], "UP"),
+					Tunnels:           2,
+					FailedTunnels:     1,
+					BytesIn:           1024,
+					BytesOut:          2048,
+					PacketsIn:         10,
+					PacketsOut:        20,
+					DroppedPacketsIn:  1,
+					DroppedPacketsOut: 2,
+					TunnelMetrics: []ipsecVPNTunnelMetric{
+						{
+							LocalSubnet:       "10.0.0.0/24",
+							PeerSubnet:        "10.1.0.0/24",
+							StatusDetail:      buildExpectedIPSecVPNStatusDetail(ipsecVPNTunnelPossibleStatus[:], "UP"),
+							BytesIn:           1024,
+							BytesOut:          2048,
+							PacketsIn:         10,
+							PacketsOut:        20,
+							DroppedPacketsIn:  1,
+							DroppedPacketsOut: 2,
+						}, {
+							LocalSubnet:  "10.0.0.0/24",
+							PeerSubnet:   "10.2.0.0/24",
+							DownReason:   "No proposal chosen",
+							StatusDetail: buildExpectedIPSecVPNStatusDetail(ipsecVPNTunnelPossibleStatus[:], "DOWN"),
+						},
+					},
+				}, {
+					ID:              fakeIPSecVPNSessionID("02"),
+					Name:            fakeIPSecVPNSessionName("02"),
+					LocalEndpoint:   fakeIPSecVPNLocalAddress,
+					PeerAddress:     "",
+					StatusDetail:    buildExpectedIPSecVPNStatusDetail(ipsecVPNSessionPossibleStatus[:], "DOWN"),
+					IKEStatusDetail: buildExpectedIPSecVPNStatusDetail(ipsecVPNIKEPossibleStatus[:], "DOWN"),
+					IKEFailReason:   "Peer not responding",
+					Tunnels:         1,
+					FailedTunnels:   1,
+				},
+			},
+		}, {
+			description: "Should skip sessions without status",
+			sessionStatusResponses: []ipsecVPNSessionStatusResponse{
+				{
+					SessionID: fakeIPSecVPNSessionID("01"),
+					Error:     errors.New("error getting ipsec vpn session status"),
+				},
+			},
+			expectedMetrics: []ipsecVPNSessionMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockIPSecVPNClient{
+			sessionStatusResponses:     tc.sessionStatusResponses,
+			sessionStatisticsResponses: tc.sessionStatisticsResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newIPSecVPNCollector(mockClient, logger)
+		metrics := collector.generateIPSecVPNSessionMetrics(sessions, localEndpoints, peerEndpoints)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
@@ -0,0 +1,222 @@
+package collector
+
+import (
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+)
+
+var l2vpnPossibleStatus = [...]string{"UP", "DOWN"}
+
+func init() {
+	registerCollector("l2vpn", createL2VPNCollectorFactory)
+}
+
+type l2vpnCollector struct {
+	l2vpnClient client.L2VPNClient
+	logger      log.Logger
+
+	sessionStatus              *prometheus.Desc
+	tunnelStatus               *prometheus.Desc
+	logicalSwitchBytesIn       *prometheus.Desc
+	logicalSwitchBytesOut      *prometheus.Desc
+	logicalSwitchPacketsIn     *prometheus.Desc
+	logicalSwitchPacketsOut    *prometheus.Desc
+	logicalSwitchBumPacketsIn  *prometheus.Desc
+	logicalSwitchBumPacketsOut *prometheus.Desc
+}
+
+type l2vpnSessionMetric struct {
+	ID                   string
+	Name                 string
+	StatusDetail         map[string]float64
+	TunnelMetrics        []l2vpnTunnelMetric
+	LogicalSwitchMetrics []l2vpnLogicalSwitchMetric
+}
+
+type l2vpnTunnelMetric struct {
+	ID           string
+	Name         string
+	StatusDetail map[string]float64
+}
+
+type l2vpnLogicalSwitchMetric struct {
+	ID            string
+	Name          string
+	BytesIn       float64
+	BytesOut      float64
+	PacketsIn     float64
+	PacketsOut    float64
+	BumPacketsIn  float64
+	BumPacketsOut float64
+}
+
+func createL2VPNCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newL2VPNCollector(nsxtClient, logger)
+}
+
+func newL2VPNCollector(l2vpnClient client.L2VPNClient, logger log.Logger) *l2vpnCollector {
+	logicalSwitchLabels := []string{"session_id", "session_name", "logical_switch_id", "logical_switch_name"}
+	sessionStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "session_status"),
+		"Status of L2VPN session",
+		[]string{"session_id", "session_name", "status"},
+		nil,
+	)
+	tunnelStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "tunnel_status"),
+		"Status of L2VPN session transport tunnel",
+		[]string{"session_id", "session_name", "tunnel_id", "tunnel_name", "status"},
+		nil,
+	)
+	logicalSwitchBytesIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_bytes_in"),
+		"Total bytes received on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	logicalSwitchBytesOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_bytes_out"),
+		"Total bytes sent on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	logicalSwitchPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_packets_in"),
+		"Total packets received on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	logicalSwitchPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_packets_out"),
+		"Total packets sent on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	logicalSwitchBumPacketsIn := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_bum_packets_in"),
+		"Total broadcast, unknown unicast and multicast packets received on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	logicalSwitchBumPacketsOut := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "l2vpn", "logical_switch_bum_packets_out"),
+		"Total broadcast, unknown unicast and multicast packets sent on logical switch stretched over L2VPN session",
+		logicalSwitchLabels,
+		nil,
+	)
+	return &l2vpnCollector{
+		l2vpnClient: l2vpnClient,
+		logger:      logger,
+
+		sessionStatus:              sessionStatus,
+		tunnelStatus:               tunnelStatus,
+		logicalSwitchBytesIn:       logicalSwitchBytesIn,
+		logicalSwitchBytesOut:      logicalSwitchBytesOut,
+		logicalSwitchPacketsIn:     logicalSwitchPacketsIn,
+		logicalSwitchPacketsOut:    logicalSwitchPacketsOut,
+		logicalSwitchBumPacketsIn:  logicalSwitchBumPacketsIn,
+		logicalSwitchBumPacketsOut: logicalSwitchBumPacketsOut,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *l2vpnCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sessionStatus
+	ch <- c.tunnelStatus
+	ch <- c.logicalSwitchBytesIn
+	ch <- c.logicalSwitchBytesOut
+	ch <- c.logicalSwitchPacketsIn
+	ch <- c.logicalSwitchPacketsOut
+	ch <- c.logicalSwitchBumPacketsIn
+	ch <- c.logicalSwitchBumPacketsOut
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *l2vpnCollector) Collect(ch chan<- prometheus.Metric) {
+	sessions, err := c.l2vpnClient.ListAllL2VPNSessions()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list L2VPN sessions", "err", err)
+		return
+	}
+	sessionMetrics := c.generateL2VPNSessionMetrics(sessions)
+	for _, m := range sessionMetrics {
+		for status, value := range m.StatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.sessionStatus, prometheus.GaugeValue, value, m.ID, m.Name, status)
+		}
+		for _, tunnel := range m.TunnelMetrics {
+			for status, value := range tunnel.StatusDetail {
+				ch <- prometheus.MustNewConstMetric(c.tunnelStatus, prometheus.GaugeValue, value, m.ID, m.Name, tunnel.ID, tunnel.Name, status)
+			}
+		}
+		for _, ls := range m.LogicalSwitchMetrics {
+			labels := []string{m.ID, m.Name, ls.ID, ls.Name}
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchBytesIn, prometheus.GaugeValue, ls.BytesIn, labels...)
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchBytesOut, prometheus.GaugeValue, ls.BytesOut, labels...)
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchPacketsIn, prometheus.GaugeValue, ls.PacketsIn, labels...)
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchPacketsOut, prometheus.GaugeValue, ls.PacketsOut, labels...)
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchBumPacketsIn, prometheus.GaugeValue, ls.BumPacketsIn, labels...)
+			ch <- prometheus.MustNewConstMetric(c.logicalSwitchBumPacketsOut, prometheus.GaugeValue, ls.BumPacketsOut, labels...)
+		}
+	}
+}
+
+func (c *l2vpnCollector) generateL2VPNSessionMetrics(sessions []client.L2VPNSession) (sessionMetrics []l2vpnSessionMetric) {
+	for _, session := range sessions {
+		sessionStatus, err := c.l2vpnClient.GetL2VPNSessionStatus(session.ID)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get L2VPN session status", "id", session.ID, "err", err)
+			continue
+		}
+		sessionMetric := l2vpnSessionMetric{
+			ID:           session.ID,
+			Name:         session.DisplayName,
+			StatusDetail: c.constructStatusDetail(sessionStatus.Status),
+		}
+		for _, tunnelStatus := range sessionStatus.TransportTunnels {
+			tunnelMetric := l2vpnTunnelMetric{
+				ID:           tunnelStatus.TunnelID.TargetId,
+				Name:         tunnelStatus.TunnelID.TargetDisplayName,
+				StatusDetail: c.constructStatusDetail(tunnelStatus.Status),
+			}
+			sessionMetric.TunnelMetrics = append(sessionMetric.TunnelMetrics, tunnelMetric)
+		}
+		sessionStatistics, err := c.l2vpnClient.GetL2VPNSessionStatistics(session.ID)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get L2VPN session statistics", "id", session.ID, "err", err)
+		}
+		for _, lsStatistics := range sessionStatistics.TrafficStatisticsPerLogicalSwitch {
+			logicalSwitchMetric := l2vpnLogicalSwitchMetric{
+				ID:            lsStatistics.LogicalSwitch.TargetId,
+				Name:          lsStatistics.LogicalSwitch.TargetDisplayName,
+				BytesIn:       float64(lsStatistics.BytesIn),
+				BytesOut:      float64(lsStatistics.BytesOut),
+				PacketsIn:     float64(lsStatistics.PacketsIn),
+				PacketsOut:    float64(lsStatistics.PacketsOut),
+				BumPacketsIn:  float64(lsStatistics.BumPacketsIn),
+				BumPacketsOut: float64(lsStatistics.BumPacketsOut),
+			}
+			sessionMetric.LogicalSwitchMetrics = append(sessionMetric.LogicalSwitchMetrics, logicalSwitchMetric)
+		}
+		sessionMetrics = append(sessionMetrics, sessionMetric)
+	}
+	return
+}
+
+func (c *l2vpnCollector) constructStatusDetail(currentStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range l2vpnPossibleStatus {
+		statusValue := 0.0
+		if status == strings.ToUpper(currentStatus) {
+			statusValue = 1.0
+		}
+		statusDetail[status] = statusValue
+	}
+	return statusDetail
+}
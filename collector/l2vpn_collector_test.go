@@ -0,0 +1,185 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/common"
+)
+
+func fakeL2VPNSessionID(id string) string {
+	return "fake-l2vpn-session-id-" + id
+}
+
+func fakeL2VPNSessionName(id string) string {
+	return "fake-l2vpn-session-name-" + id
+}
+
+type l2vpnSessionStatusResponse struct {
+	SessionID string
+	Status    client.L2VPNSessionStatus
+	Error     error
+}
+
+type l2vpnSessionStatisticsResponse struct {
+	SessionID  string
+	Statistics client.L2VPNSessionStatistics
+	Error      error
+}
+
+type mockL2VPNClient struct {
+	sessionStatusResponses     []l2vpnSessionStatusResponse
+	sessionStatisticsResponses []l2vpnSessionStatisticsResponse
+}
+
+func (c *mockL2VPNClient) ListAllL2VPNSessions() ([]client.L2VPNSession, error) {
+	panic("unused function. Only used to satisfy L2VPNClient interface")
+}
+
+func (c *mockL2VPNClient) GetL2VPNSessionStatus(sessionID string) (client.L2VPNSessionStatus, error) {
+	for _, res := range c.sessionStatusResponses {
+		if res.SessionID == sessionID {
+			return res.Status, res.Error
+		}
+	}
+	return client.L2VPNSessionStatus{}, errors.New("l2vpn session status not found")
+}
+
+func (c *mockL2VPNClient) GetL2VPNSessionStatistics(sessionID string) (client.L2VPNSessionStatistics, error) {
+	for _, res := range c.sessionStatisticsResponses {
+		if res.SessionID == sessionID {
+			return res.Statistics, res.Error
+		}
+	}
+	return client.L2VPNSessionStatistics{}, errors.New("l2vpn session statistics not found")
+}
+
+func buildExpectedL2VPNStatusDetail(nonZeroStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range l2vpnPossibleStatus {
+		statusDetail[status] = 0.0
+	}
+	statusDetail[nonZeroStatus] = 1.0
+	return statusDetail
+}
+
+func TestL2VPNCollector_GenerateL2VPNSessionMetrics(t *testing.T) {
+	sessions := []client.L2VPNSession{
+		{
+			ID:          fakeL2VPNSessionID("01"),
+			DisplayName: fakeL2VPNSessionName("01"),
+		}, {
+			ID:          fakeL2VPNSessionID("02"),
+			DisplayName: fakeL2VPNSessionName("02"),
+		},
+	}
+	testcases := []struct {
+		description                string
+		sessionStatusResponses     []l2vpnSessionStatusResponse
+		sessionStatisticsResponses []l2vpnSessionStatisticsResponse
+		expectedMetrics            []l2vpnSessionMetric
+	}{
+		{
+			description: "Should return session, tunnel and logical switch metrics",
+			sessionStatusResponses: []l2vpnSessionStatusResponse{
+				{
+					SessionID: fakeL2VPNSessionID("01"),
+					Status: client.L2VPNSessionStatus{
+						Status: "UP",
+						TransportTunnels: []client.L2VPNTunnelStatus{
+							{
+								TunnelID: common.ResourceReference{
+									TargetId:          fakeIPSecVPNSessionID("01"),
+									TargetDisplayName: fakeIPSecVPNSessionName("01"),
+								},
+								Status: "up",
+							},
+						},
+					},
+				}, {
+					SessionID: fakeL2VPNSessionID("02"),
+					Status: client.L2VPNSessionStatus{
+						Status: "DOWN",
+					},
+				},
+			},
+			sessionStatisticsResponses: []l2vpnSessionStatisticsResponse{
+				{
+					SessionID: fakeL2VPNSessionID("01"),
+					Statistics: client.L2VPNSessionStatistics{
+						TrafficStatisticsPerLogicalSwitch: []client.L2VPNLogicalSwitchTrafficStatistics{
+							{
+								LogicalSwitch: common.ResourceReference{
+									TargetId:          fakeLogicalSwitchID + "-01",
+									TargetDisplayName: fakeLogicalSwitchDisplayName + "-01",
+								},
+								BytesIn:       1024,
+								BytesOut:      2048,
+								PacketsIn:     10,
+								PacketsOut:    20,
+								BumPacketsIn:  1,
+								BumPacketsOut: 2,
+							},
+						},
+					},
+				}, {
+					SessionID: fakeL2VPNSessionID("02"),
+					Error:     errors.New("error getting l2vpn session statistics"),
+				},
+			},
+			expectedMetrics: []l2vpnSessionMetric{
+				{
+					ID:           fakeL2VPNSessionID("01"),
+					Name:         fakeL2VPNSessionName("01"),
+					StatusDetail: buildExpectedL2VPNStatusDetail("UP"),
+					TunnelMetrics: []l2vpnTunnelMetric{
+						{
+							ID:           fakeIPSecVPNSessionID("01"),
+							Name:         fakeIPSecVPNSessionName("01"),
+							StatusDetail: buildExpectedL2VPNStatusDetail("UP"),
+						},
+					},
+					LogicalSwitchMetrics: []l2vpnLogicalSwitchMetric{
+						{
+							ID:            fakeLogicalSwitchID + "-01",
+							Name:          fakeLogicalSwitchDisplayName + "-01",
+							BytesIn:       1024,
+							BytesOut:      2048,
+							PacketsIn:     10,
+							PacketsOut:    20,
+							BumPacketsIn:  1,
+							BumPacketsOut: 2,
+						},
+					},
+				}, {
+					ID:           fakeL2VPNSessionID("02"),
+					Name:         fakeL2VPNSessionName("02"),
+					StatusDetail: buildExpectedL2VPNStatusDetail("DOWN"),
+				},
+			},
+		}, {
+			description: "Should skip sessions without status",
+			sessionStatusResponses: []l2vpnSessionStatusResponse{
+				{
+					SessionID: fakeL2VPNSessionID("01"),
+					Error:     errors.New("error getting l2vpn session status"),
+				},
+			},
+			expectedMetrics: []l2vpnSessionMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockL2VPNClient{
+			sessionStatusResponses:     tc.sessionStatusResponses,
+			sessionStatisticsResponses: tc.sessionStatisticsResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newL2VPNCollector(mockClient, logger)
+		metrics := collector.generateL2VPNSessionMetrics(sessions)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
@@ -0,0 +1,170 @@
+package collector
+
+import (
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/licensing"
+)
+
+// licenseKeySuffixLength is the number of trailing license key characters exposed as label,
+// so licenses can be told apart without leaking the full key.
+const licenseKeySuffixLength = 5
+
+func init() {
+	registerCollector("license", createLicenseCollectorFactory)
+}
+
+type licenseCollector struct {
+	licenseClient client.LicenseClient
+	logger        log.Logger
+
+	licenseExpiry       *prometheus.Desc
+	licenseExpired      *prometheus.Desc
+	licenseCapacity     *prometheus.Desc
+	licenseFeatureUsage *prometheus.Desc
+}
+
+type licenseMetric struct {
+	KeySuffix       string
+	Features        string
+	ProductName     string
+	ProductVersion  string
+	CapacityType    string
+	ExpiryTimestamp float64
+	Expired         float64
+	Capacity        float64
+}
+
+type licenseFeatureUsageMetric struct {
+	Feature      string
+	CapacityType string
+	Usage        float64
+}
+
+func createLicenseCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newLicenseCollector(nsxtClient, logger)
+}
+
+func newLicenseCollector(licenseClient client.LicenseClient, logger log.Logger) *licenseCollector {
+	licenseLabels := []string{"license_key_suffix", "features", "product_name", "product_version", "capacity_type"}
+	licenseExpiry := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "license", "expiry_timestamp_seconds"),
+		"Timestamp when license expires",
+		licenseLabels,
+		nil,
+	)
+	licenseExpired := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "license", "expired"),
+		"Whether license has expired",
+		licenseLabels,
+		nil,
+	)
+	licenseCapacity := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "license", "capacity"),
+		"License capacity of capacity type, 0 for unlimited",
+		licenseLabels,
+		nil,
+	)
+	licenseFeatureUsage := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "license", "feature_usage"),
+		"License usage of feature per capacity type",
+		[]string{"feature", "capacity_type"},
+		nil,
+	)
+	return &licenseCollector{
+		licenseClient: licenseClient,
+		logger:        logger,
+
+		licenseExpiry:       licenseExpiry,
+		licenseExpired:      licenseExpired,
+		licenseCapacity:     licenseCapacity,
+		licenseFeatureUsage: licenseFeatureUsage,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *licenseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.licenseExpiry
+	ch <- c.licenseExpired
+	ch <- c.licenseCapacity
+	ch <- c.licenseFeatureUsage
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *licenseCollector) Collect(ch chan<- prometheus.Metric) {
+	licenses, err := c.licenseClient.ListAllLicenses()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list licenses", "err", err)
+	} else {
+		for _, m := range c.generateLicenseMetrics(licenses) {
+			labels := []string{m.KeySuffix, m.Features, m.ProductName, m.ProductVersion, m.CapacityType}
+			ch <- prometheus.MustNewConstMetric(c.licenseExpiry, prometheus.GaugeValue, m.ExpiryTimestamp, labels...)
+			ch <- prometheus.MustNewConstMetric(c.licenseExpired, prometheus.GaugeValue, m.Expired, labels...)
+			ch <- prometheus.MustNewConstMetric(c.licenseCapacity, prometheus.GaugeValue, m.Capacity, labels...)
+		}
+	}
+	usageReport, err := c.licenseClient.GetLicenseUsageReport()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get license usage report", "err", err)
+		return
+	}
+	for _, m := range c.generateLicenseFeatureUsageMetrics(usageReport) {
+		ch <- prometheus.MustNewConstMetric(c.licenseFeatureUsage, prometheus.GaugeValue, m.Usage, m.Feature, m.CapacityType)
+	}
+}
+
+func (c *licenseCollector) generateLicenseMetrics(licenses []licensing.License) (licenseMetrics []licenseMetric) {
+	seenLicenses := make(map[licenseMetric]bool)
+	for _, license := range licenses {
+		keySuffix := license.LicenseKey
+		if len(keySuffix) > licenseKeySuffixLength {
+			keySuffix = keySuffix[len(keySuffix)-licenseKeySuffixLength:]
+		}
+		// Licenses are told apart by their labels only, so emitting the same label set twice would fail the scrape.
+		licenseLabels := licenseMetric{
+			KeySuffix:      keySuffix,
+			Features:       license.Features,
+			ProductName:    license.ProductName,
+			ProductVersion: license.ProductVersion,
+			CapacityType:   license.CapacityType,
+		}
+		if seenLicenses[licenseLabels] {
+			level.Warn(c.logger).Log("msg", "Skipping license with duplicate labels", "license_key_suffix", keySuffix, "features", license.Features)
+			continue
+		}
+		seenLicenses[licenseLabels] = true
+		licenseMetric := licenseMetric{
+			KeySuffix:       keySuffix,
+			Features:        license.Features,
+			ProductName:     license.ProductName,
+			ProductVersion:  license.ProductVersion,
+			CapacityType:    license.CapacityType,
+			ExpiryTimestamp: float64(license.Expiry) / 1000,
+			Capacity:        float64(license.Quantity),
+		}
+		if license.IsExpired {
+			licenseMetric.Expired = 1.0
+		}
+		licenseMetrics = append(licenseMetrics, licenseMetric)
+	}
+	return
+}
+
+func (c *licenseCollector) generateLicenseFeatureUsageMetrics(usageReport licensing.FeatureUsageList) (licenseFeatureUsageMetrics []licenseFeatureUsageMetric) {
+	for _, featureUsage := range usageReport.FeatureUsageInfo {
+		for _, capacityUsage := range featureUsage.CapacityUsage {
+			licenseFeatureUsageMetric := licenseFeatureUsageMetric{
+				Feature:      featureUsage.Feature,
+				CapacityType: capacityUsage.CapacityType,
+				Usage:        float64(capacityUsage.UsageCount),
+			}
+			licenseFeatureUsageMetrics = append(licenseFeatureUsageMetrics, licenseFeatureUsageMetric)
+		}
+	}
+	return
+}
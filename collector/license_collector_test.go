@@ -0,0 +1,155 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/licensing"
+)
+
+const (
+	fakeLicenseProductName    = "NSX Data Center Enterprise Plus"
+	fakeLicenseProductVersion = "3.0"
+)
+
+type mockLicenseClient struct{}
+
+func (c *mockLicenseClient) ListAllLicenses() ([]licensing.License, error) {
+	panic("unused function. Only used to satisfy LicenseClient interface")
+}
+
+func (c *mockLicenseClient) GetLicenseUsageReport() (licensing.FeatureUsageList, error) {
+	panic("unused function. Only used to satisfy LicenseClient interface")
+}
+
+func TestLicenseCollector_GenerateLicenseMetrics(t *testing.T) {
+	testcases := []struct {
+		description     string
+		licenses        []licensing.License
+		expectedMetrics []licenseMetric
+	}{
+		{
+			description: "Should return expiry and capacity with masked license key",
+			licenses: []licensing.License{
+				{
+					LicenseKey:     "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE",
+					ProductName:    fakeLicenseProductName,
+					ProductVersion: fakeLicenseProductVersion,
+					CapacityType:   "CPU",
+					Features:       "DFW, LB",
+					Expiry:         1622505600000,
+					Quantity:       64,
+				}, {
+					LicenseKey:     "FFFFF-GGGGG-HHHHH-IIIII-JJJJJ",
+					ProductName:    fakeLicenseProductName,
+					ProductVersion: fakeLicenseProductVersion,
+					CapacityType:   "VM",
+					Expiry:         1590969600000,
+					IsExpired:      true,
+				},
+			},
+			expectedMetrics: []licenseMetric{
+				{
+					KeySuffix:       "EEEEE",
+					Features:        "DFW, LB",
+					ProductName:     fakeLicenseProductName,
+					ProductVersion:  fakeLicenseProductVersion,
+					CapacityType:    "CPU",
+					ExpiryTimestamp: 1622505600,
+					Expired:         0.0,
+					Capacity:        64,
+				}, {
+					KeySuffix:       "JJJJJ",
+					ProductName:     fakeLicenseProductName,
+					ProductVersion:  fakeLicenseProductVersion,
+					CapacityType:    "VM",
+					ExpiryTimestamp: 1590969600,
+					Expired:         1.0,
+					Capacity:        0,
+				},
+			},
+		}, {
+			description: "Should tell apart licenses sharing key suffix by features and skip duplicates",
+			licenses: []licensing.License{
+				{
+					LicenseKey:   "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE",
+					ProductName:  fakeLicenseProductName,
+					CapacityType: "CPU",
+					Features:     "DFW",
+					Quantity:     16,
+				}, {
+					LicenseKey:   "FFFFF-GGGGG-HHHHH-IIIII-EEEEE",
+					ProductName:  fakeLicenseProductName,
+					CapacityType: "CPU",
+					Features:     "LB",
+					Quantity:     32,
+				}, {
+					LicenseKey:   "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE",
+					ProductName:  fakeLicenseProductName,
+					CapacityType: "CPU",
+					Features:     "DFW",
+					Quantity:     16,
+				},
+			},
+			expectedMetrics: []licenseMetric{
+				{
+					KeySuffix:    "EEEEE",
+					Features:     "DFW",
+					ProductName:  fakeLicenseProductName,
+					CapacityType: "CPU",
+					Capacity:     16,
+				}, {
+					KeySuffix:    "EEEEE",
+					Features:     "LB",
+					ProductName:  fakeLicenseProductName,
+					CapacityType: "CPU",
+					Capacity:     32,
+				},
+			},
+		}, {
+			description:     "Should return empty metrics when there is no license",
+			licenses:        []licensing.License{},
+			expectedMetrics: []licenseMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockLicenseClient{}
+		logger := log.NewNopLogger()
+		collector := newLicenseCollector(mockClient, logger)
+		metrics := collector.generateLicenseMetrics(tc.licenses)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
+
+func TestLicenseCollector_GenerateLicenseFeatureUsageMetrics(t *testing.T) {
+	usageReport := licensing.FeatureUsageList{
+		FeatureUsageInfo: []licensing.FeatureUsage{
+			{
+				Feature: "Distributed Firewall",
+				CapacityUsage: []licensing.CapacityUsage{
+					{CapacityType: "CPU", UsageCount: 48},
+					{CapacityType: "VM", UsageCount: 300},
+				},
+			}, {
+				Feature: "VPN",
+			},
+		},
+	}
+	expectedMetrics := []licenseFeatureUsageMetric{
+		{
+			Feature:      "Distributed Firewall",
+			CapacityType: "CPU",
+			Usage:        48,
+		}, {
+			Feature:      "Distributed Firewall",
+			CapacityType: "VM",
+			Usage:        300,
+		},
+	}
+	mockClient := &mockLicenseClient{}
+	logger := log.NewNopLogger()
+	collector := newLicenseCollector(mockClient, logger)
+	metrics := collector.generateLicenseFeatureUsageMetrics(usageReport)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
@@ -2,6 +2,8 @@ package collector
 
 import (
 	"strings"
+	"sync"
+	"time"
 
 	"nsxt_exporter/client"
 
@@ -10,29 +12,47 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/loadbalancer"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 var loadBalancerPossibleStatus = []string{"UP", "DOWN", "ERROR", "NO_STANDBY", "DETACHED", "DISABLED", "UNKNOWN"}
 var loadBalancerPoolPossibleStatus = []string{"UP", "PARTIALLY_UP", "PRIMARY_DOWN", "DOWN", "DETACHED", "UNKNOWN"}
+var loadBalancerVirtualServerPossibleStatus = []string{"UP", "PARTIALLY_UP", "DOWN", "DETACHED", "DISABLED", "UNKNOWN"}
 var loadBalancerPoolMemberPossibleStatus = []string{"UP", "DOWN", "DISABLED", "GRACEFUL_DISABLED", "UNUSED"}
 
+var (
+	loadBalancerConfigRefreshInterval = kingpin.Flag("collector.load_balancer.config_refresh_interval", "Interval to refresh cached load balancer pool, virtual server and health monitor configuration.").Default("5m").Duration()
+)
+
 func init() {
 	registerCollector("load_balancer", createLoadBalancerCollectorFactory)
 }
 
 type loadBalancerCollector struct {
-	client client.LoadBalancerClient
-	logger log.Logger
-
-	loadBalancerStatus            *prometheus.Desc
-	loadBalancerPoolStatus        *prometheus.Desc
-	loadBalancerPoolMemberStatus  *prometheus.Desc
-	loadBalancerL4CurrentSessions *prometheus.Desc
-	loadBalancerL4MaxSessions     *prometheus.Desc
-	loadBalancerL4TotalSessions   *prometheus.Desc
-	loadBalancerL7CurrentSessions *prometheus.Desc
-	loadBalancerL7MaxSessions     *prometheus.Desc
-	loadBalancerL7TotalSessions   *prometheus.Desc
+	client                client.LoadBalancerClient
+	logger                log.Logger
+	configRefreshInterval time.Duration
+
+	configMutex       sync.Mutex
+	configRefreshedAt time.Time
+	config            loadBalancerConfiguration
+
+	loadBalancerStatus                    *prometheus.Desc
+	loadBalancerPoolStatus                *prometheus.Desc
+	loadBalancerPoolMemberStatus          *prometheus.Desc
+	loadBalancerVirtualServerStatus       *prometheus.Desc
+	loadBalancerPoolInfo                  *prometheus.Desc
+	loadBalancerPoolMemberInfo            *prometheus.Desc
+	loadBalancerVirtualServerInfo         *prometheus.Desc
+	loadBalancerPoolHealthMonitor         *prometheus.Desc
+	loadBalancerPoolMemberFailure         *prometheus.Desc
+	loadBalancerPoolMemberLastStateChange *prometheus.Desc
+	loadBalancerL4CurrentSessions         *prometheus.Desc
+	loadBalancerL4MaxSessions             *prometheus.Desc
+	loadBalancerL4TotalSessions           *prometheus.Desc
+	loadBalancerL7CurrentSessions         *prometheus.Desc
+	loadBalancerL7MaxSessions             *prometheus.Desc
+	loadBalancerL7TotalSessions           *prometheus.Desc
 
 	loadBalancerPoolBytesIn                      *prometheus.Desc
 	loadBalancerPoolBytesOut                     *prometheus.Desc
@@ -65,23 +85,51 @@ type loadBalancerCollector struct {
 	loadBalancerVirtualServerTotalSessions                *prometheus.Desc
 }
 
+type loadBalancerConfiguration struct {
+	Pools          []loadbalancer.LbPool
+	VirtualServers []loadbalancer.LbVirtualServer
+	Monitors       []client.LoadBalancerMonitor
+}
+
 type loadBalancerStatusMetric struct {
+	ID                   string
+	Name                 string
+	StatusDetail         map[string]float64
+	PoolsStatus          []loadBalancerPoolStatusMetric
+	VirtualServersStatus []loadBalancerVirtualServerStatusMetric
+}
+
+type loadBalancerVirtualServerStatusMetric struct {
 	ID           string
 	Name         string
+	IPAddress    string
+	Port         string
+	PoolID       string
 	StatusDetail map[string]float64
-	PoolsStatus  []loadBalancerPoolStatusMetric
 }
 
 type loadBalancerPoolStatusMetric struct {
-	ID            string
-	StatusDetail  map[string]float64
-	MembersStatus []loadBalancerPoolMemberStatusMetric
+	ID             string
+	Name           string
+	StatusDetail   map[string]float64
+	HealthMonitors []loadBalancerPoolHealthMonitorMetric
+	MembersStatus  []loadBalancerPoolMemberStatusMetric
+}
+
+type loadBalancerPoolHealthMonitorMetric struct {
+	ID   string
+	Name string
+	Type string
+	Port string
 }
 
 type loadBalancerPoolMemberStatusMetric struct {
-	IPAddress    string
-	Port         string
-	StatusDetail map[string]float64
+	Name                     string
+	IPAddress                string
+	Port                     string
+	StatusDetail             map[string]float64
+	FailureCause             string
+	LastStateChangeTimestamp float64
 }
 
 type loadBalancerStatisticMetric struct {
@@ -140,10 +188,10 @@ type loadBalancerPoolMemberStatisticMetric struct {
 
 func createLoadBalancerCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
 	nsxtClient := client.NewNSXTClient(apiClient, logger)
-	return newLoadBalancerCollector(nsxtClient, logger)
+	return newLoadBalancerCollector(nsxtClient, logger, *loadBalancerConfigRefreshInterval)
 }
 
-func newLoadBalancerCollector(client client.LoadBalancerClient, logger log.Logger) *loadBalancerCollector {
+func newLoadBalancerCollector(client client.LoadBalancerClient, logger log.Logger, configRefreshInterval time.Duration) *loadBalancerCollector {
 	loadBalancerStatus := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "load_balancer", "status"),
 		"Status of Load Balancer",
@@ -162,6 +210,48 @@ func newLoadBalancerCollector(client client.LoadBalancerClient, logger log.Logge
 		[]string{"ip_address", "port", "load_balancer_pool_id", "load_balancer_id", "status"},
 		nil,
 	)
+	loadBalancerVirtualServerStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "virtual_server_status"),
+		"Status of Load Balancer virtual server",
+		[]string{"id", "name", "ip_address", "port", "load_balancer_id", "status"},
+		nil,
+	)
+	loadBalancerPoolInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "pool_info"),
+		"Info of Load Balancer pool",
+		[]string{"id", "name", "load_balancer_id"},
+		nil,
+	)
+	loadBalancerPoolMemberInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "pool_member_info"),
+		"Info of Load Balancer pool member",
+		[]string{"ip_address", "port", "load_balancer_pool_id", "load_balancer_id", "name"},
+		nil,
+	)
+	loadBalancerVirtualServerInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "virtual_server_info"),
+		"Info of Load Balancer virtual server",
+		[]string{"id", "name", "ip_address", "port", "load_balancer_pool_id", "load_balancer_id"},
+		nil,
+	)
+	loadBalancerPoolHealthMonitor := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "pool_health_monitor_info"),
+		"Info of health monitor used by Load Balancer pool",
+		[]string{"load_balancer_pool_id", "load_balancer_id", "monitor_id", "monitor_name", "type", "port"},
+		nil,
+	)
+	loadBalancerPoolMemberFailure := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "pool_member_failure_cause"),
+		"Cause of Load Balancer pool member failure",
+		[]string{"ip_address", "port", "load_balancer_pool_id", "load_balancer_id", "failure_cause"},
+		nil,
+	)
+	loadBalancerPoolMemberLastStateChange := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer", "pool_member_last_state_change_timestamp_seconds"),
+		"Timestamp of last Load Balancer pool member state change",
+		[]string{"ip_address", "port", "load_balancer_pool_id", "load_balancer_id"},
+		nil,
+	)
 	loadBalancerL4CurrentSessions := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "load_balancer", "l4_current_sessions"),
 		"Number of Load Balancer L4 current sessions",
@@ -361,18 +451,26 @@ func newLoadBalancerCollector(client client.LoadBalancerClient, logger log.Logge
 		nil,
 	)
 	return &loadBalancerCollector{
-		client: client,
-		logger: logger,
-
-		loadBalancerStatus:            loadBalancerStatus,
-		loadBalancerPoolStatus:        loadBalancerPoolStatus,
-		loadBalancerPoolMemberStatus:  loadBalancerPoolMemberStatus,
-		loadBalancerL4CurrentSessions: loadBalancerL4CurrentSessions,
-		loadBalancerL4MaxSessions:     loadBalancerL4MaxSessions,
-		loadBalancerL4TotalSessions:   loadBalancerL4TotalSessions,
-		loadBalancerL7CurrentSessions: loadBalancerL7CurrentSessions,
-		loadBalancerL7MaxSessions:     loadBalancerL7MaxSessions,
-		loadBalancerL7TotalSessions:   loadBalancerL7TotalSessions,
+		client:                client,
+		logger:                logger,
+		configRefreshInterval: configRefreshInterval,
+
+		loadBalancerStatus:                    loadBalancerStatus,
+		loadBalancerPoolStatus:                loadBalancerPoolStatus,
+		loadBalancerPoolMemberStatus:          loadBalancerPoolMemberStatus,
+		loadBalancerVirtualServerStatus:       loadBalancerVirtualServerStatus,
+		loadBalancerPoolInfo:                  loadBalancerPoolInfo,
+		loadBalancerPoolMemberInfo:            loadBalancerPoolMemberInfo,
+		loadBalancerVirtualServerInfo:         loadBalancerVirtualServerInfo,
+		loadBalancerPoolHealthMonitor:         loadBalancerPoolHealthMonitor,
+		loadBalancerPoolMemberFailure:         loadBalancerPoolMemberFailure,
+		loadBalancerPoolMemberLastStateChange: loadBalancerPoolMemberLastStateChange,
+		loadBalancerL4CurrentSessions:         loadBalancerL4CurrentSessions,
+		loadBalancerL4MaxSessions:             loadBalancerL4MaxSessions,
+		loadBalancerL4TotalSessions:           loadBalancerL4TotalSessions,
+		loadBalancerL7CurrentSessions:         loadBalancerL7CurrentSessions,
+		loadBalancerL7MaxSessions:             loadBalancerL7MaxSessions,
+		loadBalancerL7TotalSessions:           loadBalancerL7TotalSessions,
 
 		loadBalancerPoolBytesIn:                      loadBalancerPoolBytesIn,
 		loadBalancerPoolBytesOut:                     loadBalancerPoolBytesOut,
@@ -411,6 +509,13 @@ func (c *loadBalancerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.loadBalancerStatus
 	ch <- c.loadBalancerPoolStatus
 	ch <- c.loadBalancerPoolMemberStatus
+	ch <- c.loadBalancerVirtualServerStatus
+	ch <- c.loadBalancerPoolInfo
+	ch <- c.loadBalancerPoolMemberInfo
+	ch <- c.loadBalancerVirtualServerInfo
+	ch <- c.loadBalancerPoolHealthMonitor
+	ch <- c.loadBalancerPoolMemberFailure
+	ch <- c.loadBalancerPoolMemberLastStateChange
 	ch <- c.loadBalancerL4CurrentSessions
 	ch <- c.loadBalancerL4MaxSessions
 	ch <- c.loadBalancerL4TotalSessions
@@ -457,21 +562,40 @@ func (c *loadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 		level.Error(c.logger).Log("msg", "Unable to list load balancers", "err", err)
 		return
 	}
-	statusMetrics := c.generateLoadBalancerStatusMetrics(loadBalancers)
+	config := c.loadBalancerConfig(time.Now())
+	statusMetrics := c.generateLoadBalancerStatusMetrics(loadBalancers, config)
 	for _, metric := range statusMetrics {
 		for status, value := range metric.StatusDetail {
 			ch <- prometheus.MustNewConstMetric(c.loadBalancerStatus, prometheus.GaugeValue, value, metric.ID, metric.Name, status)
 		}
 		for _, poolStatus := range metric.PoolsStatus {
+			ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolInfo, prometheus.GaugeValue, 1.0, poolStatus.ID, poolStatus.Name, metric.ID)
 			for status, value := range poolStatus.StatusDetail {
 				ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolStatus, prometheus.GaugeValue, value, poolStatus.ID, metric.ID, status)
 			}
+			for _, monitor := range poolStatus.HealthMonitors {
+				ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolHealthMonitor, prometheus.GaugeValue, 1.0, poolStatus.ID, metric.ID, monitor.ID, monitor.Name, monitor.Type, monitor.Port)
+			}
 			for _, memberStatus := range poolStatus.MembersStatus {
+				memberLabels := []string{memberStatus.IPAddress, memberStatus.Port, poolStatus.ID, metric.ID}
+				ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolMemberInfo, prometheus.GaugeValue, 1.0, append(memberLabels, memberStatus.Name)...)
+				if memberStatus.FailureCause != "" {
+					ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolMemberFailure, prometheus.GaugeValue, 1.0, append(memberLabels, memberStatus.FailureCause)...)
+				}
+				if memberStatus.LastStateChangeTimestamp > 0 {
+					ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolMemberLastStateChange, prometheus.GaugeValue, memberStatus.LastStateChangeTimestamp, memberLabels...)
+				}
 				for status, value := range memberStatus.StatusDetail {
 					ch <- prometheus.MustNewConstMetric(c.loadBalancerPoolMemberStatus, prometheus.GaugeValue, value, memberStatus.IPAddress, memberStatus.Port, poolStatus.ID, metric.ID, status)
 				}
 			}
 		}
+		for _, virtualServerStatus := range metric.VirtualServersStatus {
+			ch <- prometheus.MustNewConstMetric(c.loadBalancerVirtualServerInfo, prometheus.GaugeValue, 1.0, virtualServerStatus.ID, virtualServerStatus.Name, virtualServerStatus.IPAddress, virtualServerStatus.Port, virtualServerStatus.PoolID, metric.ID)
+			for status, value := range virtualServerStatus.StatusDetail {
+				ch <- prometheus.MustNewConstMetric(c.loadBalancerVirtualServerStatus, prometheus.GaugeValue, value, virtualServerStatus.ID, virtualServerStatus.Name, virtualServerStatus.IPAddress, virtualServerStatus.Port, metric.ID, status)
+			}
+		}
 	}
 	statisticMetrics := c.generateLoadBalancerStatisticMetrics(loadBalancers)
 	for _, metric := range statisticMetrics {
@@ -521,7 +645,52 @@ func (c *loadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 	return
 }
 
-func (c *loadBalancerCollector) generateLoadBalancerStatusMetrics(loadBalancers []loadbalancer.LbService) (loadBalancerStatusMetrics []loadBalancerStatusMetric) {
+// loadBalancerConfig returns pool, virtual server and health monitor configuration used to resolve
+// display names. The configuration is cached and refreshed once the refresh interval has passed;
+// the previous configuration is kept when refreshing fails.
+func (c *loadBalancerCollector) loadBalancerConfig(now time.Time) loadBalancerConfiguration {
+	c.configMutex.Lock()
+	defer c.configMutex.Unlock()
+	if !c.configRefreshedAt.IsZero() && now.Sub(c.configRefreshedAt) < c.configRefreshInterval {
+		return c.config
+	}
+	pools, err := c.client.ListAllLoadBalancerPools()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list load balancer pools", "err", err)
+		return c.config
+	}
+	virtualServers, err := c.client.ListAllLoadBalancerVirtualServers()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list load balancer virtual servers", "err", err)
+		return c.config
+	}
+	monitors, err := c.client.ListAllLoadBalancerMonitors()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list load balancer monitors", "err", err)
+		return c.config
+	}
+	c.config = loadBalancerConfiguration{
+		Pools:          pools,
+		VirtualServers: virtualServers,
+		Monitors:       monitors,
+	}
+	c.configRefreshedAt = now
+	return c.config
+}
+
+func (c *loadBalancerCollector) generateLoadBalancerStatusMetrics(loadBalancers []loadbalancer.LbService, config loadBalancerConfiguration) (loadBalancerStatusMetrics []loadBalancerStatusMetric) {
+	poolByID := make(map[string]loadbalancer.LbPool)
+	for _, pool := range config.Pools {
+		poolByID[pool.Id] = pool
+	}
+	virtualServerByID := make(map[string]loadbalancer.LbVirtualServer)
+	for _, virtualServer := range config.VirtualServers {
+		virtualServerByID[virtualServer.Id] = virtualServer
+	}
+	monitorByID := make(map[string]client.LoadBalancerMonitor)
+	for _, monitor := range config.Monitors {
+		monitorByID[monitor.ID] = monitor
+	}
 	for _, lb := range loadBalancers {
 		lbStatus, err := c.client.GetLoadBalancerStatus(lb.Id)
 		if err != nil {
@@ -534,25 +703,74 @@ func (c *loadBalancerCollector) generateLoadBalancerStatusMetrics(loadBalancers
 			StatusDetail: c.constructStatusDetail(loadBalancerPossibleStatus, lbStatus.ServiceStatus),
 		}
 		for _, poolStatus := range lbStatus.Pools {
+			pool := poolByID[poolStatus.PoolId]
 			poolStatusMetric := loadBalancerPoolStatusMetric{
 				ID:           poolStatus.PoolId,
+				Name:         pool.DisplayName,
 				StatusDetail: c.constructStatusDetail(loadBalancerPoolPossibleStatus, poolStatus.Status),
 			}
+			monitorIDs := append([]string{}, pool.ActiveMonitorIds...)
+			if pool.PassiveMonitorId != "" {
+				monitorIDs = append(monitorIDs, pool.PassiveMonitorId)
+			}
+			for _, monitorID := range monitorIDs {
+				monitor := monitorByID[monitorID]
+				poolStatusMetric.HealthMonitors = append(poolStatusMetric.HealthMonitors, loadBalancerPoolHealthMonitorMetric{
+					ID:   monitorID,
+					Name: monitor.DisplayName,
+					Type: monitor.ResourceType,
+					Port: monitor.MonitorPort,
+				})
+			}
 			for _, memberStatus := range poolStatus.Members {
 				memberStatusMetric := loadBalancerPoolMemberStatusMetric{
-					IPAddress:    memberStatus.IPAddress,
-					Port:         memberStatus.Port,
-					StatusDetail: c.constructStatusDetail(loadBalancerPoolMemberPossibleStatus, memberStatus.Status),
+					Name:                     poolMemberName(pool, memberStatus),
+					IPAddress:                memberStatus.IPAddress,
+					Port:                     memberStatus.Port,
+					StatusDetail:             c.constructStatusDetail(loadBalancerPoolMemberPossibleStatus, memberStatus.Status),
+					FailureCause:             memberStatus.FailureCause,
+					LastStateChangeTimestamp: float64(memberStatus.LastStateChangeTime) / 1000,
 				}
 				poolStatusMetric.MembersStatus = append(poolStatusMetric.MembersStatus, memberStatusMetric)
 			}
 			loadBalancerStatusMetric.PoolsStatus = append(loadBalancerStatusMetric.PoolsStatus, poolStatusMetric)
 		}
+		for _, virtualServerStatus := range lbStatus.VirtualServers {
+			virtualServer := virtualServerByID[virtualServerStatus.VirtualServerId]
+			virtualServerStatusMetric := loadBalancerVirtualServerStatusMetric{
+				ID:           virtualServerStatus.VirtualServerId,
+				Name:         virtualServer.DisplayName,
+				IPAddress:    virtualServer.IpAddress,
+				Port:         virtualServerPort(virtualServer),
+				PoolID:       virtualServer.PoolId,
+				StatusDetail: c.constructStatusDetail(loadBalancerVirtualServerPossibleStatus, virtualServerStatus.Status),
+			}
+			loadBalancerStatusMetric.VirtualServersStatus = append(loadBalancerStatusMetric.VirtualServersStatus, virtualServerStatusMetric)
+		}
 		loadBalancerStatusMetrics = append(loadBalancerStatusMetrics, loadBalancerStatusMetric)
 	}
 	return
 }
 
+// poolMemberName returns the display name of pool member matching member status.
+// Members without configured port use the virtual server port, so they are matched by IP address only.
+func poolMemberName(pool loadbalancer.LbPool, memberStatus loadbalancer.LbPoolMemberStatus) string {
+	for _, member := range pool.Members {
+		if member.IpAddress == memberStatus.IPAddress && (member.Port == "" || member.Port == memberStatus.Port) {
+			return member.DisplayName
+		}
+	}
+	return ""
+}
+
+// virtualServerPort returns the ports of a virtual server, preferring the deprecated single port when it is set.
+func virtualServerPort(virtualServer loadbalancer.LbVirtualServer) string {
+	if virtualServer.Port != "" {
+		return virtualServer.Port
+	}
+	return strings.Join(virtualServer.Ports, ",")
+}
+
 func (c *loadBalancerCollector) constructStatusDetail(possibleStatus []string, currentStatus string) map[string]float64 {
 	statusDetail := map[string]float64{}
 	for _, status := range possibleStatus {
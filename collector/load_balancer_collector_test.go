@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"nsxt_exporter/client"
 
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
@@ -53,39 +56,69 @@ const (
 )
 
 type mockLoadBalancerClient struct {
-	responses []mockLoadBalancerResponse
+	responses        []mockLoadBalancerResponse
+	pools            []loadbalancer.LbPool
+	virtualServers   []loadbalancer.LbVirtualServer
+	configError      error
+	listConfigCalled int
 }
 
 type mockLoadBalancerResponse struct {
-	ID               string
-	Name             string
-	Status           string
-	PoolID           string
-	PoolStatus       string
-	PoolMemberStatus string
-	VirtualServerID  string
-	Error            error
+	ID                            string
+	Name                          string
+	Status                        string
+	PoolID                        string
+	PoolStatus                    string
+	PoolMemberStatus              string
+	VirtualServerID               string
+	VirtualServerStatus           string
+	PoolMemberFailureCause        string
+	PoolMemberLastStateChangeTime int64
+	Error                         error
 }
 
 func (c *mockLoadBalancerClient) ListAllLoadBalancers() ([]loadbalancer.LbService, error) {
 	panic("unused function. Only used to satisfy LoadBalancerClient interface")
 }
 
+func (c *mockLoadBalancerClient) ListAllLoadBalancerVirtualServers() ([]loadbalancer.LbVirtualServer, error) {
+	return c.virtualServers, c.configError
+}
+
+func (c *mockLoadBalancerClient) ListAllLoadBalancerPools() ([]loadbalancer.LbPool, error) {
+	c.listConfigCalled++
+	return c.pools, c.configError
+}
+
+func (c *mockLoadBalancerClient) ListAllLoadBalancerMonitors() ([]client.LoadBalancerMonitor, error) {
+	return nil, c.configError
+}
+
 func (c *mockLoadBalancerClient) GetLoadBalancerStatus(loadBalancerID string) (loadbalancer.LbServiceStatus, error) {
 	for _, res := range c.responses {
 		if res.ID == loadBalancerID {
+			var virtualServers []loadbalancer.LbVirtualServerStatus
+			if res.VirtualServerStatus != "" {
+				virtualServers = append(virtualServers, loadbalancer.LbVirtualServerStatus{
+					VirtualServerId: res.VirtualServerID,
+					Status:          res.VirtualServerStatus,
+				})
+			}
 			return loadbalancer.LbServiceStatus{
-				ServiceId:     res.ID,
-				ServiceStatus: res.Status,
+				VirtualServers: virtualServers,
+				ServiceId:      res.ID,
+				ServiceStatus:  res.Status,
 				Pools: []loadbalancer.LbPoolStatus{
 					{
 						PoolId: res.PoolID,
 						Status: res.PoolStatus,
 						Members: []loadbalancer.LbPoolMemberStatus{
 							{
-								IPAddress: fakeLoadbalancerPoolMemberIP,
-								Port:      fakeLoadbalancerPoolMemberPort,
-								Status:    res.PoolMemberStatus,
+								IPAddress:           fakeLoadbalancerPoolMemberIP,
+								Port:                fakeLoadbalancerPoolMemberPort,
+								Status:              res.PoolMemberStatus,
+								FailureCause:        res.PoolMemberFailureCause,
+								LastStateChangeTime: res.PoolMemberLastStateChangeTime,
 							},
 						},
 					},
@@ -470,12 +503,209 @@ func TestLoadBalancerCollector_GenerateLoadBalancerStatusMetrics(t *testing.T) {
 		}
 		loadBalancers := buildLoadBalancers(tc.loadBalancerResponses)
 		logger := log.NewNopLogger()
-		loadBalancerCollector := newLoadBalancerCollector(mockLoadBalancerClient, logger)
-		loadBalancerStatusMetrics := loadBalancerCollector.generateLoadBalancerStatusMetrics(loadBalancers)
+		loadBalancerCollector := newLoadBalancerCollector(mockLoadBalancerClient, logger, time.Minute)
+		loadBalancerStatusMetrics := loadBalancerCollector.generateLoadBalancerStatusMetrics(loadBalancers, loadBalancerConfiguration{})
 		assert.ElementsMatch(t, tc.expectedMetrics, loadBalancerStatusMetrics, tc.description)
 	}
 }
 
+func buildExpectedLoadBalancerVirtualServerStatusDetails(nonZeroStatus string) map[string]float64 {
+	statusDetails := map[string]float64{
+		"UP":           0.0,
+		"PARTIALLY_UP": 0.0,
+		"DOWN":         0.0,
+		"DETACHED":     0.0,
+		"DISABLED":     0.0,
+		"UNKNOWN":      0.0,
+	}
+	statusDetails[nonZeroStatus] = 1.0
+	return statusDetails
+}
+
+func TestLoadBalancerCollector_GenerateLoadBalancerVirtualServerStatusMetrics(t *testing.T) {
+	loadBalancerResponses := []mockLoadBalancerResponse{
+		buildLoadBalancerStatusResponse("01", "UP", "UP", "UP", nil),
+		buildLoadBalancerStatusResponse("02", "UP", "UP", "UP", nil),
+	}
+	loadBalancerResponses[0].VirtualServerStatus = "PARTIALLY_UP"
+	loadBalancerResponses[1].VirtualServerStatus = "disabled"
+	virtualServers := []loadbalancer.LbVirtualServer{
+		{
+			Id:          fakeLoadBalancerVirtualServerID + "-01",
+			DisplayName: "fake-load-balancer-virtual-server-name-01",
+			IpAddress:   "10.0.0.1",
+			Port:        "443",
+		}, {
+			Id:          fakeLoadBalancerVirtualServerID + "-02",
+			DisplayName: "fake-load-balancer-virtual-server-name-02",
+			IpAddress:   "10.0.0.2",
+			Ports:       []string{"80", "8080-8090"},
+		},
+	}
+	expectedVirtualServersStatus := [][]loadBalancerVirtualServerStatusMetric{
+		{
+			{
+				ID:           fakeLoadBalancerVirtualServerID + "-01",
+				Name:         "fake-load-balancer-virtual-server-name-01",
+				IPAddress:    "10.0.0.1",
+				Port:         "443",
+				StatusDetail: buildExpectedLoadBalancerVirtualServerStatusDetails("PARTIALLY_UP"),
+			},
+		}, {
+			{
+				ID:           fakeLoadBalancerVirtualServerID + "-02",
+				Name:         "fake-load-balancer-virtual-server-name-02",
+				IPAddress:    "10.0.0.2",
+				Port:         "80,8080-8090",
+				StatusDetail: buildExpectedLoadBalancerVirtualServerStatusDetails("DISABLED"),
+			},
+		},
+	}
+	mockLoadBalancerClient := &mockLoadBalancerClient{
+		responses:      loadBalancerResponses,
+		virtualServers: virtualServers,
+	}
+	loadBalancers := buildLoadBalancers(loadBalancerResponses)
+	logger := log.NewNopLogger()
+	loadBalancerCollector := newLoadBalancerCollector(mockLoadBalancerClient, logger, time.Minute)
+	loadBalancerStatusMetrics := loadBalancerCollector.generateLoadBalancerStatusMetrics(loadBalancers, loadBalancerConfiguration{VirtualServers: virtualServers})
+	assert.Len(t, loadBalancerStatusMetrics, len(expectedVirtualServersStatus))
+	for i, metric := range loadBalancerStatusMetrics {
+		assert.ElementsMatch(t, expectedVirtualServersStatus[i], metric.VirtualServersStatus)
+	}
+}
+
+func TestLoadBalancerCollector_GenerateLoadBalancerStatusMetricsWithNames(t *testing.T) {
+	loadBalancerResponses := []mockLoadBalancerResponse{
+		buildLoadBalancerStatusResponse("01", "UP", "UP", "UP", nil),
+	}
+	loadBalancerResponses[0].VirtualServerStatus = "UP"
+	loadBalancerResponses[0].PoolMemberFailureCause = "Monitor status is down"
+	loadBalancerResponses[0].PoolMemberLastStateChangeTime = 1590969600000
+	pools := []loadbalancer.LbPool{
+		{
+			Id:               fakeLoadBalancerPoolID + "-01",
+			DisplayName:      "fake-load-balancer-pool-name-01",
+			ActiveMonitorIds: []string{"fake-load-balancer-monitor-id-01"},
+			PassiveMonitorId: "fake-load-balancer-monitor-id-02",
+			Members: []loadbalancer.PoolMember{
+				{
+					DisplayName: "fake-load-balancer-pool-member-name-01",
+					IpAddress:   fakeLoadbalancerPoolMemberIP,
+				},
+			},
+		},
+	}
+	virtualServers := []loadbalancer.LbVirtualServer{
+		{
+			Id:          fakeLoadBalancerVirtualServerID + "-01",
+			DisplayName: "fake-load-balancer-virtual-server-name-01",
+			IpAddress:   "10.0.0.1",
+			Port:        "443",
+			PoolId:      fakeLoadBalancerPoolID + "-01",
+		},
+	}
+	monitors := []client.LoadBalancerMonitor{
+		{
+			ID:           "fake-load-balancer-monitor-id-01",
+			DisplayName:  "fake-load-balancer-monitor-name-01",
+			ResourceType: "LbHttpMonitor",
+			MonitorPort:  "8080",
+		}, {
+			ID:           "fake-load-balancer-monitor-id-02",
+			DisplayName:  "fake-load-balancer-monitor-name-02",
+			ResourceType: "LbPassiveMonitor",
+		},
+	}
+	expectedMetrics := []loadBalancerStatusMetric{
+		{
+			ID:           "fake-load-balancer-id-01",
+			Name:         "fake-load-balancer-name-01",
+			StatusDetail: buildExpectedLoadBalancerStatusDetails("UP"),
+			PoolsStatus: []loadBalancerPoolStatusMetric{
+				{
+					ID:           "fake-load-balancer-pool-id-01",
+					Name:         "fake-load-balancer-pool-name-01",
+					StatusDetail: buildExpectedLoadBalancerPoolStatusDetails("UP"),
+					HealthMonitors: []loadBalancerPoolHealthMonitorMetric{
+						{
+							ID:   "fake-load-balancer-monitor-id-01",
+							Name: "fake-load-balancer-monitor-name-01",
+							Type: "LbHttpMonitor",
+							Port: "8080",
+						}, {
+							ID:   "fake-load-balancer-monitor-id-02",
+							Name: "fake-load-balancer-monitor-name-02",
+							Type: "LbPassiveMonitor",
+						},
+					},
+					MembersStatus: []loadBalancerPoolMemberStatusMetric{
+						{
+							Name:                     "fake-load-balancer-pool-member-name-01",
+							IPAddress:                fakeLoadbalancerPoolMemberIP,
+							Port:                     fakeLoadbalancerPoolMemberPort,
+							StatusDetail:             buildExpectedLoadBalancerPoolMemberStatusDetails("UP"),
+							FailureCause:             "Monitor status is down",
+							LastStateChangeTimestamp: 1590969600,
+						},
+					},
+				},
+			},
+			VirtualServersStatus: []loadBalancerVirtualServerStatusMetric{
+				{
+					ID:           fakeLoadBalancerVirtualServerID + "-01",
+					Name:         "fake-load-balancer-virtual-server-name-01",
+					IPAddress:    "10.0.0.1",
+					Port:         "443",
+					PoolID:       fakeLoadBalancerPoolID + "-01",
+					StatusDetail: buildExpectedLoadBalancerVirtualServerStatusDetails("UP"),
+				},
+			},
+		},
+	}
+	mockLoadBalancerClient := &mockLoadBalancerClient{
+		responses: loadBalancerResponses,
+	}
+	loadBalancers := buildLoadBalancers(loadBalancerResponses)
+	logger := log.NewNopLogger()
+	loadBalancerCollector := newLoadBalancerCollector(mockLoadBalancerClient, logger, time.Minute)
+	config := loadBalancerConfiguration{
+		Pools:          pools,
+		VirtualServers: virtualServers,
+		Monitors:       monitors,
+	}
+	loadBalancerStatusMetrics := loadBalancerCollector.generateLoadBalancerStatusMetrics(loadBalancers, config)
+	assert.ElementsMatch(t, expectedMetrics, loadBalancerStatusMetrics)
+}
+
+func TestLoadBalancerCollector_LoadBalancerConfig(t *testing.T) {
+	now := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	pools := []loadbalancer.LbPool{
+		{
+			Id:          fakeLoadBalancerPoolID + "-01",
+			DisplayName: "fake-load-balancer-pool-name-01",
+		},
+	}
+	mockLoadBalancerClient := &mockLoadBalancerClient{
+		pools: pools,
+	}
+	logger := log.NewNopLogger()
+	loadBalancerCollector := newLoadBalancerCollector(mockLoadBalancerClient, logger, time.Minute)
+
+	config := loadBalancerCollector.loadBalancerConfig(now)
+	assert.Equal(t, pools, config.Pools, "Should list configuration on first scrape")
+	assert.Equal(t, 1, mockLoadBalancerClient.listConfigCalled)
+
+	config = loadBalancerCollector.loadBalancerConfig(now.Add(30 * time.Second))
+	assert.Equal(t, pools, config.Pools, "Should return cached configuration within refresh interval")
+	assert.Equal(t, 1, mockLoadBalancerClient.listConfigCalled)
+
+	mockLoadBalancerClient.configError = errors.New("error listing load balancer pools")
+	config = loadBalancerCollector.loadBalancerConfig(now.Add(2 * time.Minute))
+	assert.Equal(t, pools, config.Pools, "Should keep cached configuration when refresh fails")
+	assert.Equal(t, 2, mockLoadBalancerClient.listConfigCalled)
+}
+
 func TestLoadBalancerCollector_GenerateLoadBalancerStatisticMetrics(t *testing.T) {
 	testcases := []struct {
 		description           string
@@ -511,7 +741,7 @@ func TestLoadBalancerCollector_GenerateLoadBalancerStatisticMetrics(t *testing.T
 		}
 		loadBalancers := buildLoadBalancers(tc.loadBalancerResponses)
 		logger := log.NewNopLogger()
-		loadBalancerCollector := newLoadBalancerCollector(client, logger)
+		loadBalancerCollector := newLoadBalancerCollector(client, logger, time.Minute)
 		loadBalancerStatisticMetrics := loadBalancerCollector.generateLoadBalancerStatisticMetrics(loadBalancers)
 		assert.ElementsMatch(t, tc.expectedMetrics, loadBalancerStatisticMetrics, tc.description)
 	}
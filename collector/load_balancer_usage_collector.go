@@ -0,0 +1,241 @@
+package collector
+
+import (
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/loadbalancer"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func init() {
+	registerCollector("load_balancer_usage", createLoadBalancerUsageCollectorFactory)
+}
+
+type loadBalancerUsageCollector struct {
+	loadBalancerUsageClient client.LoadBalancerUsageClient
+	logger                  log.Logger
+
+	serviceInfo                  *prometheus.Desc
+	serviceUsage                 *prometheus.Desc
+	serviceVirtualServers        *prometheus.Desc
+	serviceVirtualServerCapacity *prometheus.Desc
+	servicePools                 *prometheus.Desc
+	servicePoolCapacity          *prometheus.Desc
+	servicePoolMembers           *prometheus.Desc
+	servicePoolMemberCapacity    *prometheus.Desc
+	nodeUsage                    *prometheus.Desc
+	nodeCredits                  *prometheus.Desc
+	nodeCreditCapacity           *prometheus.Desc
+}
+
+type loadBalancerServiceUsageMetric struct {
+	ID                    string
+	Name                  string
+	Size                  string
+	UsagePercentage       float64
+	VirtualServers        float64
+	VirtualServerCapacity float64
+	Pools                 float64
+	PoolCapacity          float64
+	PoolMembers           float64
+	PoolMemberCapacity    float64
+}
+
+type loadBalancerNodeUsageMetric struct {
+	TransportNodeID string
+	EdgeClusterID   string
+	UsagePercentage float64
+	Credits         float64
+	CreditCapacity  float64
+}
+
+func createLoadBalancerUsageCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newLoadBalancerUsageCollector(nsxtClient, logger)
+}
+
+func newLoadBalancerUsageCollector(loadBalancerUsageClient client.LoadBalancerUsageClient, logger log.Logger) *loadBalancerUsageCollector {
+	serviceLabels := []string{"id", "name"}
+	nodeLabels := []string{"transport_node_id", "edge_cluster_id"}
+	serviceInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "info"),
+		"Info of Load Balancer service size",
+		[]string{"id", "name", "size"},
+		nil,
+	)
+	serviceUsage := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "percent"),
+		"Usage percentage of Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	serviceVirtualServers := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "virtual_servers"),
+		"Number of virtual servers in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	serviceVirtualServerCapacity := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "virtual_server_capacity"),
+		"Maximum number of virtual servers in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	servicePools := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "pools"),
+		"Number of pools in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	servicePoolCapacity := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "pool_capacity"),
+		"Maximum number of pools in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	servicePoolMembers := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "pool_members"),
+		"Number of pool members in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	servicePoolMemberCapacity := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_usage", "pool_member_capacity"),
+		"Maximum number of pool members in Load Balancer service",
+		serviceLabels,
+		nil,
+	)
+	nodeUsage := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_node_usage", "percent"),
+		"Load Balancer usage percentage of edge node",
+		nodeLabels,
+		nil,
+	)
+	nodeCredits := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_node_usage", "credits"),
+		"Number of Load Balancer credits consumed on edge node",
+		nodeLabels,
+		nil,
+	)
+	nodeCreditCapacity := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "load_balancer_node_usage", "credit_capacity"),
+		"Maximum number of Load Balancer credits on edge node",
+		nodeLabels,
+		nil,
+	)
+	return &loadBalancerUsageCollector{
+		loadBalancerUsageClient: loadBalancerUsageClient,
+		logger:                  logger,
+
+		serviceInfo:                  serviceInfo,
+		serviceUsage:                 serviceUsage,
+		serviceVirtualServers:        serviceVirtualServers,
+		serviceVirtualServerCapacity: serviceVirtualServerCapacity,
+		servicePools:                 servicePools,
+		servicePoolCapacity:          servicePoolCapacity,
+		servicePoolMembers:           servicePoolMembers,
+		servicePoolMemberCapacity:    servicePoolMemberCapacity,
+		nodeUsage:                    nodeUsage,
+		nodeCredits:                  nodeCredits,
+		nodeCreditCapacity:           nodeCreditCapacity,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *loadBalancerUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.serviceInfo
+	ch <- c.serviceUsage
+	ch <- c.serviceVirtualServers
+	ch <- c.serviceVirtualServerCapacity
+	ch <- c.servicePools
+	ch <- c.servicePoolCapacity
+	ch <- c.servicePoolMembers
+	ch <- c.servicePoolMemberCapacity
+	ch <- c.nodeUsage
+	ch <- c.nodeCredits
+	ch <- c.nodeCreditCapacity
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *loadBalancerUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	loadBalancers, err := c.loadBalancerUsageClient.ListAllLoadBalancers()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list load balancers", "err", err)
+	} else {
+		for _, m := range c.generateLoadBalancerServiceUsageMetrics(loadBalancers) {
+			labels := []string{m.ID, m.Name}
+			ch <- prometheus.MustNewConstMetric(c.serviceInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.Size)
+			ch <- prometheus.MustNewConstMetric(c.serviceUsage, prometheus.GaugeValue, m.UsagePercentage, labels...)
+			ch <- prometheus.MustNewConstMetric(c.serviceVirtualServers, prometheus.GaugeValue, m.VirtualServers, labels...)
+			ch <- prometheus.MustNewConstMetric(c.serviceVirtualServerCapacity, prometheus.GaugeValue, m.VirtualServerCapacity, labels...)
+			ch <- prometheus.MustNewConstMetric(c.servicePools, prometheus.GaugeValue, m.Pools, labels...)
+			ch <- prometheus.MustNewConstMetric(c.servicePoolCapacity, prometheus.GaugeValue, m.PoolCapacity, labels...)
+			ch <- prometheus.MustNewConstMetric(c.servicePoolMembers, prometheus.GaugeValue, m.PoolMembers, labels...)
+			ch <- prometheus.MustNewConstMetric(c.servicePoolMemberCapacity, prometheus.GaugeValue, m.PoolMemberCapacity, labels...)
+		}
+	}
+	edgeClusters, err := c.loadBalancerUsageClient.ListAllEdgeClusters()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list edge clusters", "err", err)
+		return
+	}
+	for _, m := range c.generateLoadBalancerNodeUsageMetrics(edgeClusters) {
+		labels := []string{m.TransportNodeID, m.EdgeClusterID}
+		ch <- prometheus.MustNewConstMetric(c.nodeUsage, prometheus.GaugeValue, m.UsagePercentage, labels...)
+		ch <- prometheus.MustNewConstMetric(c.nodeCredits, prometheus.GaugeValue, m.Credits, labels...)
+		ch <- prometheus.MustNewConstMetric(c.nodeCreditCapacity, prometheus.GaugeValue, m.CreditCapacity, labels...)
+	}
+}
+
+func (c *loadBalancerUsageCollector) generateLoadBalancerServiceUsageMetrics(loadBalancers []loadbalancer.LbService) (serviceUsageMetrics []loadBalancerServiceUsageMetric) {
+	for _, lb := range loadBalancers {
+		usage, err := c.loadBalancerUsageClient.GetLoadBalancerServiceUsage(lb.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get load balancer service usage", "id", lb.Id, "err", err)
+			continue
+		}
+		size := usage.ServiceSize
+		if size == "" {
+			size = lb.Size
+		}
+		serviceUsageMetric := loadBalancerServiceUsageMetric{
+			ID:                    lb.Id,
+			Name:                  lb.DisplayName,
+			Size:                  size,
+			UsagePercentage:       usage.UsagePercentage,
+			VirtualServers:        float64(usage.CurrentVirtualServerCount),
+			VirtualServerCapacity: float64(usage.VirtualServerCapacity),
+			Pools:                 float64(usage.CurrentPoolCount),
+			PoolCapacity:          float64(usage.PoolCapacity),
+			PoolMembers:           float64(usage.CurrentPoolMemberCount),
+			PoolMemberCapacity:    float64(usage.PoolMemberCapacity),
+		}
+		serviceUsageMetrics = append(serviceUsageMetrics, serviceUsageMetric)
+	}
+	return
+}
+
+func (c *loadBalancerUsageCollector) generateLoadBalancerNodeUsageMetrics(edgeClusters []manager.EdgeCluster) (nodeUsageMetrics []loadBalancerNodeUsageMetric) {
+	for _, ec := range edgeClusters {
+		for _, member := range ec.Members {
+			usage, err := c.loadBalancerUsageClient.GetLoadBalancerNodeUsage(member.TransportNodeId)
+			if err != nil {
+				level.Error(c.logger).Log("msg", "Unable to get load balancer node usage", "id", member.TransportNodeId, "err", err)
+				continue
+			}
+			nodeUsageMetric := loadBalancerNodeUsageMetric{
+				TransportNodeID: member.TransportNodeId,
+				EdgeClusterID:   ec.Id,
+				UsagePercentage: usage.UsagePercentage,
+				Credits:         float64(usage.CurrentCreditNumber),
+				CreditCapacity:  float64(usage.CreditCapacity),
+			}
+			nodeUsageMetrics = append(nodeUsageMetrics, nodeUsageMetric)
+		}
+	}
+	return
+}
@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/loadbalancer"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+type loadBalancerServiceUsageResponse struct {
+	LoadBalancerID string
+	Usage          client.LoadBalancerServiceUsage
+	Error          error
+}
+
+type loadBalancerNodeUsageResponse struct {
+	NodeID string
+	Usage  client.LoadBalancerNodeUsage
+	Error  error
+}
+
+type mockLoadBalancerUsageClient struct {
+	serviceUsageResponses []loadBalancerServiceUsageResponse
+	nodeUsageResponses    []loadBalancerNodeUsageResponse
+}
+
+func (c *mockLoadBalancerUsageClient) ListAllLoadBalancers() ([]loadbalancer.LbService, error) {
+	panic("unused function. Only used to satisfy LoadBalancerUsageClient interface")
+}
+
+func (c *mockLoadBalancerUsageClient) ListAllEdgeClusters() ([]manager.EdgeCluster, error) {
+	panic("unused function. Only used to satisfy LoadBalancerUsageClient interface")
+}
+
+func (c *mockLoadBalancerUsageClient) GetLoadBalancerServiceUsage(loadBalancerID string) (client.LoadBalancerServiceUsage, error) {
+	for _, res := range c.serviceUsageResponses {
+		if res.LoadBalancerID == loadBalancerID {
+			return res.Usage, res.Error
+		}
+	}
+	return client.LoadBalancerServiceUsage{}, errors.New("load balancer service usage not found")
+}
+
+func (c *mockLoadBalancerUsageClient) GetLoadBalancerNodeUsage(nodeID string) (client.LoadBalancerNodeUsage, error) {
+	for _, res := range c.nodeUsageResponses {
+		if res.NodeID == nodeID {
+			return res.Usage, res.Error
+		}
+	}
+	return client.LoadBalancerNodeUsage{}, errors.New("load balancer node usage not found")
+}
+
+func TestLoadBalancerUsageCollector_GenerateLoadBalancerServiceUsageMetrics(t *testing.T) {
+	loadBalancers := []loadbalancer.LbService{
+		{
+			Id:          fakeLoadBalancerID + "-01",
+			DisplayName: fakeLoadBalancerName + "-01",
+			Size:        "SMALL",
+		}, {
+			Id:          fakeLoadBalancerID + "-02",
+			DisplayName: fakeLoadBalancerName + "-02",
+			Size:        "MEDIUM",
+		},
+	}
+	testcases := []struct {
+		description           string
+		serviceUsageResponses []loadBalancerServiceUsageResponse
+		expectedMetrics       []loadBalancerServiceUsageMetric
+	}{
+		{
+			description: "Should return usage against capacity per load balancer service",
+			serviceUsageResponses: []loadBalancerServiceUsageResponse{
+				{
+					LoadBalancerID: fakeLoadBalancerID + "-01",
+					Usage: client.LoadBalancerServiceUsage{
+						ServiceSize:               "LARGE",
+						UsagePercentage:           12.5,
+						CurrentVirtualServerCount: 10,
+						VirtualServerCapacity:     1000,
+						CurrentPoolCount:          20,
+						PoolCapacity:              1000,
+						CurrentPoolMemberCount:    80,
+						PoolMemberCapacity:        7500,
+					},
+				}, {
+					LoadBalancerID: fakeLoadBalancerID + "-02",
+					Usage: client.LoadBalancerServiceUsage{
+						VirtualServerCapacity: 100,
+						PoolCapacity:          100,
+						PoolMemberCapacity:    1000,
+					},
+				},
+			},
+			expectedMetrics: []loadBalancerServiceUsageMetric{
+				{
+					ID:                    fakeLoadBalancerID + "-01",
+					Name:                  fakeLoadBalancerName + "-01",
+					Size:                  "LARGE",
+					UsagePercentage:       12.5,
+					VirtualServers:        10,
+					VirtualServerCapacity: 1000,
+					Pools:                 20,
+					PoolCapacity:          1000,
+					PoolMembers:           80,
+					PoolMemberCapacity:    7500,
+				}, {
+					ID:                    fakeLoadBalancerID + "-02",
+					Name:                  fakeLoadBalancerName + "-02",
+					Size:                  "MEDIUM",
+					VirtualServerCapacity: 100,
+					PoolCapacity:          100,
+					PoolMemberCapacity:    1000,
+				},
+			},
+		}, {
+			description: "Should skip load balancer service without usage",
+			serviceUsageResponses: []loadBalancerServiceUsageResponse{
+				{
+					LoadBalancerID: fakeLoadBalancerID + "-01",
+					Error:          errors.New("error getting load balancer service usage"),
+				},
+			},
+			expectedMetrics: []loadBalancerServiceUsageMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockLoadBalancerUsageClient{
+			serviceUsageResponses: tc.serviceUsageResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newLoadBalancerUsageCollector(mockClient, logger)
+		metrics := collector.generateLoadBalancerServiceUsageMetrics(loadBalancers)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
+
+func TestLoadBalancerUsageCollector_GenerateLoadBalancerNodeUsageMetrics(t *testing.T) {
+	edgeClusters := []manager.EdgeCluster{
+		{
+			Id: fakeEdgeClusterID("01"),
+			Members: []manager.EdgeClusterMember{
+				{TransportNodeId: fakeTransportNodeID("01")},
+				{TransportNodeId: fakeTransportNodeID("02")},
+			},
+		},
+	}
+	mockClient := &mockLoadBalancerUsageClient{
+		nodeUsageResponses: []loadBalancerNodeUsageResponse{
+			{
+				NodeID: fakeTransportNodeID("01"),
+				Usage: client.LoadBalancerNodeUsage{
+					UsagePercentage:     50,
+					CurrentCreditNumber: 5,
+					CreditCapacity:      10,
+				},
+			}, {
+				NodeID: fakeTransportNodeID("02"),
+				Error:  errors.New("error getting load balancer node usage"),
+			},
+		},
+	}
+	expectedMetrics := []loadBalancerNodeUsageMetric{
+		{
+			TransportNodeID: fakeTransportNodeID("01"),
+			EdgeClusterID:   fakeEdgeClusterID("01"),
+			UsagePercentage: 50,
+			Credits:         5,
+			CreditCapacity:  10,
+		},
+	}
+	logger := log.NewNopLogger()
+	collector := newLoadBalancerUsageCollector(mockClient, logger)
+	metrics := collector.generateLoadBalancerNodeUsageMetrics(edgeClusters)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
@@ -1,7 +1,10 @@
 package collector
 
 import (
+	"errors"
+	"regexp"
 	"strings"
+	"sync"
 
 	"nsxt_exporter/client"
 
@@ -10,10 +13,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 var logicalPortPossibleStatus = [...]string{"UP", "DOWN", "UNKNOWN"}
 
+var (
+	logicalPortStatisticsInclude = kingpin.Flag("collector.logical_port.statistics_include", "Regexp of logical port names to include in logical port statistics. Statistics are disabled when empty.").Default("").String()
+)
+
 func init() {
 	registerCollector("logical_port", createLogicalPortCollectorFactory)
 }
@@ -21,8 +29,21 @@ func init() {
 type logicalPortCollector struct {
 	logicalPortClient client.LogicalPortClient
 	logger            log.Logger
+	statisticsInclude *regexp.Regexp
+
+	mutex                 sync.Mutex
+	bulkStatusUnsupported bool
 
 	logicalPortStatus *prometheus.Desc
+	logicalPortInfo   *prometheus.Desc
+	rxBytes           *prometheus.Desc
+	rxPackets         *prometheus.Desc
+	rxDropped         *prometheus.Desc
+	txBytes           *prometheus.Desc
+	txPackets         *prometheus.Desc
+	txDropped         *prometheus.Desc
+	learnedMACs       *prometheus.Desc
+	learnedIPs        *prometheus.Desc
 }
 
 type logicalPortStatusMetric struct {
@@ -32,34 +53,142 @@ type logicalPortStatusMetric struct {
 	LogicalSwitchID string
 }
 
+type logicalPortInfoMetric struct {
+	ID                string
+	Name              string
+	LogicalSwitchID   string
+	AttachmentType    string
+	AttachmentID      string
+	VMName            string
+	TransportNodeID   string
+	TransportNodeName string
+}
+
+type logicalPortStatisticMetric struct {
+	ID              string
+	Name            string
+	LogicalSwitchID string
+	RxBytes         float64
+	RxPackets       float64
+	RxDropped       float64
+	TxBytes         float64
+	TxPackets       float64
+	TxDropped       float64
+	LearnedMACs     float64
+	LearnedIPs      float64
+}
+
 func createLogicalPortCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
 	nsxtClient := client.NewNSXTClient(apiClient, logger)
-	return newLogicalPortCollector(nsxtClient, logger)
+	var statisticsInclude *regexp.Regexp
+	if *logicalPortStatisticsInclude != "" {
+		statisticsInclude = compileIncludeFlag("collector.logical_port.statistics_include", *logicalPortStatisticsInclude, logger)
+	}
+	return newLogicalPortCollector(nsxtClient, logger, statisticsInclude)
 }
 
-func newLogicalPortCollector(logicalPortClient client.LogicalPortClient, logger log.Logger) *logicalPortCollector {
+func newLogicalPortCollector(logicalPortClient client.LogicalPortClient, logger log.Logger, statisticsInclude *regexp.Regexp) *logicalPortCollector {
+	statisticLabels := []string{"id", "name", "logical_switch_id"}
 	logicalPortStatus := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "logical_port", "status"),
 		"Status of logical port",
 		[]string{"id", "name", "logical_switch_id", "status"},
 		nil,
 	)
+	logicalPortInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "info"),
+		"Info of logical port attachment",
+		[]string{"id", "name", "logical_switch_id", "attachment_type", "attachment_id", "vm_name", "transport_node_id", "transport_node_name"},
+		nil,
+	)
+	rxBytes := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "rx_bytes"),
+		"Total bytes received (rx) on logical port",
+		statisticLabels,
+		nil,
+	)
+	rxPackets := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "rx_packets"),
+		"Total packets received (rx) on logical port",
+		statisticLabels,
+		nil,
+	)
+	rxDropped := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "rx_dropped_packets"),
+		"Total receive (rx) packets dropped on logical port",
+		statisticLabels,
+		nil,
+	)
+	txBytes := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "tx_bytes"),
+		"Total bytes transmitted (tx) on logical port",
+		statisticLabels,
+		nil,
+	)
+	txPackets := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "tx_packets"),
+		"Total packets transmitted (tx) on logical port",
+		statisticLabels,
+		nil,
+	)
+	txDropped := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "tx_dropped_packets"),
+		"Total transmit (tx) packets dropped on logical port",
+		statisticLabels,
+		nil,
+	)
+	learnedMACs := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "learned_mac_addresses"),
+		"Number of MAC addresses learned on logical port",
+		statisticLabels,
+		nil,
+	)
+	learnedIPs := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_port", "learned_ip_addresses"),
+		"Number of IP addresses discovered on logical port",
+		statisticLabels,
+		nil,
+	)
 	return &logicalPortCollector{
 		logicalPortClient: logicalPortClient,
 		logger:            logger,
+		statisticsInclude: statisticsInclude,
 
 		logicalPortStatus: logicalPortStatus,
+		logicalPortInfo:   logicalPortInfo,
+		rxBytes:           rxBytes,
+		rxPackets:         rxPackets,
+		rxDropped:         rxDropped,
+		txBytes:           txBytes,
+		txPackets:         txPackets,
+		txDropped:         txDropped,
+		learnedMACs:       learnedMACs,
+		learnedIPs:        learnedIPs,
 	}
 }
 
 // Describe implements the prometheus.Collector interface.
 func (lpc *logicalPortCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- lpc.logicalPortStatus
+	ch <- lpc.logicalPortInfo
+	ch <- lpc.rxBytes
+	ch <- lpc.rxPackets
+	ch <- lpc.rxDropped
+	ch <- lpc.txBytes
+	ch <- lpc.txPackets
+	ch <- lpc.txDropped
+	ch <- lpc.learnedMACs
+	ch <- lpc.learnedIPs
 }
 
 // Collect implements the prometheus.Collector interface.
 func (lpc *logicalPortCollector) Collect(ch chan<- prometheus.Metric) {
-	lportStatusMetrics := lpc.generateLogicalPortStatusMetrics()
+	lports, err := lpc.listAllLogicalPorts()
+	if err != nil {
+		level.Error(lpc.logger).Log("msg", "Unable to list logical ports", "err", err)
+		return
+	}
+	lportStatusMetrics := lpc.generateLogicalPortStatusMetrics(lports)
 	for _, lportStatusMetric := range lportStatusMetrics {
 		for status, value := range lportStatusMetric.StatusDetail {
 			ch <- prometheus.MustNewConstMetric(
@@ -73,9 +202,25 @@ func (lpc *logicalPortCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 	}
+	lportInfoMetrics := lpc.generateLogicalPortInfoMetrics(lports)
+	for _, m := range lportInfoMetrics {
+		ch <- prometheus.MustNewConstMetric(lpc.logicalPortInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.LogicalSwitchID, m.AttachmentType, m.AttachmentID, m.VMName, m.TransportNodeID, m.TransportNodeName)
+	}
+	lportStatisticMetrics := lpc.generateLogicalPortStatisticMetrics(lports)
+	for _, m := range lportStatisticMetrics {
+		labels := []string{m.ID, m.Name, m.LogicalSwitchID}
+		ch <- prometheus.MustNewConstMetric(lpc.rxBytes, prometheus.GaugeValue, m.RxBytes, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.rxPackets, prometheus.GaugeValue, m.RxPackets, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.rxDropped, prometheus.GaugeValue, m.RxDropped, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.txBytes, prometheus.GaugeValue, m.TxBytes, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.txPackets, prometheus.GaugeValue, m.TxPackets, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.txDropped, prometheus.GaugeValue, m.TxDropped, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.learnedMACs, prometheus.GaugeValue, m.LearnedMACs, labels...)
+		ch <- prometheus.MustNewConstMetric(lpc.learnedIPs, prometheus.GaugeValue, m.LearnedIPs, labels...)
+	}
 }
 
-func (lpc *logicalPortCollector) generateLogicalPortStatusMetrics() (lportStatusMetrics []logicalPortStatusMetric) {
+func (lpc *logicalPortCollector) listAllLogicalPorts() ([]manager.LogicalPort, error) {
 	var lports []manager.LogicalPort
 	var cursor string
 	for {
@@ -83,8 +228,7 @@ func (lpc *logicalPortCollector) generateLogicalPortStatusMetrics() (lportStatus
 		localVarOptionals["cursor"] = cursor
 		lportsResult, err := lpc.logicalPortClient.ListLogicalPorts(localVarOptionals)
 		if err != nil {
-			level.Error(lpc.logger).Log("msg", "Unable to list logical ports", "err", err)
-			return
+			return nil, err
 		}
 		lports = append(lports, lportsResult.Results...)
 		cursor = lportsResult.Cursor
@@ -92,11 +236,46 @@ func (lpc *logicalPortCollector) generateLogicalPortStatusMetrics() (lportStatus
 			break
 		}
 	}
+	return lports, nil
+}
+
+// listBulkLogicalPortStatuses returns operational status of all logical ports keyed by logical port ID.
+// It returns false when the bulk status API is unavailable, which is remembered once the manager
+// reports that the API does not exist.
+func (lpc *logicalPortCollector) listBulkLogicalPortStatuses() (map[string]manager.LogicalPortOperationalStatus, bool) {
+	lpc.mutex.Lock()
+	defer lpc.mutex.Unlock()
+	if lpc.bulkStatusUnsupported {
+		return nil, false
+	}
+	lportStatuses, err := lpc.logicalPortClient.ListAllLogicalPortOperationalStatuses()
+	if errors.Is(err, client.ErrNotFound) {
+		level.Info(lpc.logger).Log("msg", "Bulk logical port status is not available, falling back to per port status")
+		lpc.bulkStatusUnsupported = true
+		return nil, false
+	}
+	if err != nil {
+		level.Error(lpc.logger).Log("msg", "Unable to list logical port status", "err", err)
+		return nil, false
+	}
+	lportStatusByID := make(map[string]manager.LogicalPortOperationalStatus)
+	for _, lportStatus := range lportStatuses {
+		lportStatusByID[lportStatus.LogicalPortId] = lportStatus
+	}
+	return lportStatusByID, true
+}
+
+func (lpc *logicalPortCollector) generateLogicalPortStatusMetrics(lports []manager.LogicalPort) (lportStatusMetrics []logicalPortStatusMetric) {
+	lportStatusByID, _ := lpc.listBulkLogicalPortStatuses()
 	for _, lport := range lports {
-		lportStatus, err := lpc.logicalPortClient.GetLogicalPortOperationalStatus(lport.Id, nil)
-		if err != nil {
-			level.Error(lpc.logger).Log("msg", "Unable to get logical port status", "id", lport.Id, "err", err)
-			continue
+		lportStatus, ok := lportStatusByID[lport.Id]
+		if !ok {
+			var err error
+			lportStatus, err = lpc.logicalPortClient.GetLogicalPortOperationalStatus(lport.Id, nil)
+			if err != nil {
+				level.Error(lpc.logger).Log("msg", "Unable to get logical port status", "id", lport.Id, "err", err)
+				continue
+			}
 		}
 		lportStatusMetric := logicalPortStatusMetric{
 			ID:              lport.Id,
@@ -115,3 +294,104 @@ func (lpc *logicalPortCollector) generateLogicalPortStatusMetrics() (lportStatus
 	}
 	return
 }
+
+func (lpc *logicalPortCollector) generateLogicalPortInfoMetrics(lports []manager.LogicalPort) (lportInfoMetrics []logicalPortInfoMetric) {
+	vifs, err := lpc.logicalPortClient.ListAllVifs()
+	if err != nil {
+		level.Error(lpc.logger).Log("msg", "Unable to list vifs", "err", err)
+	}
+	virtualMachines, err := lpc.logicalPortClient.ListAllVirtualMachines()
+	if err != nil {
+		level.Error(lpc.logger).Log("msg", "Unable to list virtual machines", "err", err)
+	}
+	transportNodes, err := lpc.logicalPortClient.ListAllTransportNodes()
+	if err != nil {
+		level.Error(lpc.logger).Log("msg", "Unable to list transport nodes", "err", err)
+	}
+	vifByAttachmentID := make(map[string]manager.VirtualNetworkInterface)
+	for _, vif := range vifs {
+		if vif.LportAttachmentId != "" {
+			vifByAttachmentID[vif.LportAttachmentId] = vif
+		}
+	}
+	virtualMachineByExternalID := make(map[string]manager.VirtualMachine)
+	for _, virtualMachine := range virtualMachines {
+		virtualMachineByExternalID[virtualMachine.ExternalId] = virtualMachine
+	}
+	transportNodeByNodeID := make(map[string]manager.TransportNode)
+	for _, transportNode := range transportNodes {
+		transportNodeByNodeID[transportNode.NodeId] = transportNode
+	}
+	for _, lport := range lports {
+		lportInfoMetric := logicalPortInfoMetric{
+			ID:              lport.Id,
+			Name:            lport.DisplayName,
+			LogicalSwitchID: lport.LogicalSwitchId,
+		}
+		if lport.Attachment != nil {
+			lportInfoMetric.AttachmentType = lport.Attachment.AttachmentType
+			lportInfoMetric.AttachmentID = lport.Attachment.Id
+			if vif, ok := vifByAttachmentID[lport.Attachment.Id]; ok {
+				lportInfoMetric.VMName = virtualMachineByExternalID[vif.OwnerVmId].DisplayName
+				if transportNode, ok := transportNodeByNodeID[vif.HostId]; ok {
+					lportInfoMetric.TransportNodeID = transportNode.Id
+					lportInfoMetric.TransportNodeName = transportNode.DisplayName
+				}
+			}
+		}
+		lportInfoMetrics = append(lportInfoMetrics, lportInfoMetric)
+	}
+	return
+}
+
+func (lpc *logicalPortCollector) generateLogicalPortStatisticMetrics(lports []manager.LogicalPort) (lportStatisticMetrics []logicalPortStatisticMetric) {
+	if lpc.statisticsInclude == nil {
+		return
+	}
+	for _, lport := range lports {
+		if !lpc.statisticsInclude.MatchString(lport.DisplayName) {
+			continue
+		}
+		lportStatistics, err := lpc.logicalPortClient.GetLogicalPortStatistics(lport.Id)
+		if err != nil {
+			level.Error(lpc.logger).Log("msg", "Unable to get logical port statistics", "id", lport.Id, "err", err)
+			continue
+		}
+		lportStatisticMetric := logicalPortStatisticMetric{
+			ID:              lport.Id,
+			Name:            lport.DisplayName,
+			LogicalSwitchID: lport.LogicalSwitchId,
+		}
+		if lportStatistics.RxBytes != nil {
+			lportStatisticMetric.RxBytes = float64(lportStatistics.RxBytes.Total)
+		}
+		if lportStatistics.RxPackets != nil {
+			lportStatisticMetric.RxPackets = float64(lportStatistics.RxPackets.Total)
+			lportStatisticMetric.RxDropped = float64(lportStatistics.RxPackets.Dropped)
+		}
+		if lportStatistics.TxBytes != nil {
+			lportStatisticMetric.TxBytes = float64(lportStatistics.TxBytes.Total)
+		}
+		if lportStatistics.TxPackets != nil {
+			lportStatisticMetric.TxPackets = float64(lportStatistics.TxPackets.Total)
+			lportStatisticMetric.TxDropped = float64(lportStatistics.TxPackets.Dropped)
+		}
+		if lportStatistics.MacLearning != nil {
+			lportStatisticMetric.LearnedMACs = float64(lportStatistics.MacLearning.MacsLearned)
+		}
+		lportState, err := lpc.logicalPortClient.GetLogicalPortState(lport.Id)
+		if err != nil {
+			level.Error(lpc.logger).Log("msg", "Unable to get logical port state", "id", lport.Id, "err", err)
+		} else {
+			learnedIPs := make(map[string]bool)
+			for _, binding := range lportState.DiscoveredBindings {
+				if binding.Binding != nil && binding.Binding.IpAddress != "" {
+					learnedIPs[binding.Binding.IpAddress] = true
+				}
+			}
+			lportStatisticMetric.LearnedIPs = float64(len(learnedIPs))
+		}
+		lportStatisticMetrics = append(lportStatisticMetrics, lportStatisticMetric)
+	}
+	return
+}
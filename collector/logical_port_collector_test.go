@@ -1,11 +1,22 @@
 package collector
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/apiservice"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"nsxt_exporter/client"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -17,7 +28,12 @@ const (
 
 type mockLogicalPortClient struct {
 	responses            []mockLogicalPortResponse
+	vifs                 []manager.VirtualNetworkInterface
+	virtualMachines      []manager.VirtualMachine
+	transportNodes       []manager.TransportNode
 	logicalPortListError error
+	bulkStatusError      error
+	statusCalls          int
 }
 
 type mockLogicalPortResponse struct {
@@ -25,7 +41,10 @@ type mockLogicalPortResponse struct {
 	DisplayName     string
 	Status          string
 	LogicalSwitchID string
+	Attachment      *manager.LogicalPortAttachment
 	Error           error
+	Statistics      manager.LogicalPortStatistics
+	State           manager.LogicalPortState
 }
 
 func (c *mockLogicalPortClient) ListLogicalPorts(localVarOptionals map[string]interface{}) (manager.LogicalPortListResult, error) {
@@ -38,6 +57,7 @@ func (c *mockLogicalPortClient) ListLogicalPorts(localVarOptionals map[string]in
 			Id:              response.ID,
 			DisplayName:     response.DisplayName,
 			LogicalSwitchId: response.LogicalSwitchID,
+			Attachment:      response.Attachment,
 		}
 		logicalPorts = append(logicalPorts, logicalPort)
 	}
@@ -47,6 +67,7 @@ func (c *mockLogicalPortClient) ListLogicalPorts(localVarOptionals map[string]in
 }
 
 func (c *mockLogicalPortClient) GetLogicalPortOperationalStatus(lportID string, localVarOptionals map[string]interface{}) (manager.LogicalPortOperationalStatus, error) {
+	c.statusCalls++
 	for _, res := range c.responses {
 		if res.ID == lportID {
 			return manager.LogicalPortOperationalStatus{
@@ -57,6 +78,54 @@ func (c *mockLogicalPortClient) GetLogicalPortOperationalStatus(lportID string,
 	return manager.LogicalPortOperationalStatus{}, errors.New("error")
 }
 
+func (c *mockLogicalPortClient) ListAllLogicalPortOperationalStatuses() ([]manager.LogicalPortOperationalStatus, error) {
+	c.statusCalls++
+	if c.bulkStatusError != nil {
+		return nil, c.bulkStatusError
+	}
+	var lportStatuses []manager.LogicalPortOperationalStatus
+	for _, res := range c.responses {
+		if res.Error != nil {
+			continue
+		}
+		lportStatuses = append(lportStatuses, manager.LogicalPortOperationalStatus{
+			LogicalPortId: res.ID,
+			Status:        res.Status,
+		})
+	}
+	return lportStatuses, nil
+}
+
+func (c *mockLogicalPortClient) ListAllVirtualMachines() ([]manager.VirtualMachine, error) {
+	return c.virtualMachines, nil
+}
+
+func (c *mockLogicalPortClient) ListAllVifs() ([]manager.VirtualNetworkInterface, error) {
+	return c.vifs, nil
+}
+
+func (c *mockLogicalPortClient) ListAllTransportNodes() ([]manager.TransportNode, error) {
+	return c.transportNodes, nil
+}
+
+func (c *mockLogicalPortClient) GetLogicalPortStatistics(lportID string) (manager.LogicalPortStatistics, error) {
+	for _, res := range c.responses {
+		if res.ID == lportID {
+			return res.Statistics, res.Error
+		}
+	}
+	return manager.LogicalPortStatistics{}, errors.New("logical port not found")
+}
+
+func (c *mockLogicalPortClient) GetLogicalPortState(lportID string) (manager.LogicalPortState, error) {
+	for _, res := range c.responses {
+		if res.ID == lportID {
+			return res.State, res.Error
+		}
+	}
+	return manager.LogicalPortState{}, errors.New("logical port not found")
+}
+
 func buildLogicalPortResponse(id string, status string, err error) mockLogicalPortResponse {
 	return mockLogicalPortResponse{
 		ID:              fmt.Sprintf("%s-%s", fakeLogicalPortID, id),
@@ -146,8 +215,295 @@ func TestLogicalPortCollector_GenerateLogicalPortStatusMetrics(t *testing.T) {
 			logicalPortListError: testcase.logicalPortListError,
 		}
 		logger := log.NewNopLogger()
-		logicalPortCollector := newLogicalPortCollector(mockLogicalPortClient, logger)
-		logicalPortMetrics := logicalPortCollector.generateLogicalPortStatusMetrics()
+		logicalPortCollector := newLogicalPortCollector(mockLogicalPortClient, logger, nil)
+		lports, _ := logicalPortCollector.listAllLogicalPorts()
+		logicalPortMetrics := logicalPortCollector.generateLogicalPortStatusMetrics(lports)
+		assert.ElementsMatch(t, testcase.expectedMetrics, logicalPortMetrics, testcase.description)
+	}
+}
+
+func TestLogicalPortCollector_GenerateLogicalPortStatusMetricsFallback(t *testing.T) {
+	logicalPortResponses := []mockLogicalPortResponse{
+		buildLogicalPortResponse("01", "UP", nil),
+		buildLogicalPortResponse("02", "DOWN", nil),
+	}
+	expectedMetrics := []logicalPortStatusMetric{
+		{
+			ID:              "fake-logical-port-id-01",
+			Name:            "fake-logical-port-name-01",
+			LogicalSwitchID: "fake-logical-switch-id-01",
+			StatusDetail:    buildExpectedLogicalPortStatusDetail("UP"),
+		}, {
+			ID:              "fake-logical-port-id-02",
+			Name:            "fake-logical-port-name-02",
+			LogicalSwitchID: "fake-logical-switch-id-02",
+			StatusDetail:    buildExpectedLogicalPortStatusDetail("DOWN"),
+		},
+	}
+	mockLogicalPortClient := &mockLogicalPortClient{
+		responses:       logicalPortResponses,
+		bulkStatusError: fmt.Errorf("/v1/logical-ports/operational-status: %w", client.ErrNotFound),
+	}
+	logger := log.NewNopLogger()
+	logicalPortCollector := newLogicalPortCollector(mockLogicalPortClient, logger, nil)
+	lports, _ := logicalPortCollector.listAllLogicalPorts()
+
+	logicalPortMetrics := logicalPortCollector.generateLogicalPortStatusMetrics(lports)
+	assert.ElementsMatch(t, expectedMetrics, logicalPortMetrics, "Should fall back to per port status when bulk status is unavailable")
+	assert.Equal(t, 3, mockLogicalPortClient.statusCalls)
+
+	logicalPortMetrics = logicalPortCollector.generateLogicalPortStatusMetrics(lports)
+	assert.ElementsMatch(t, expectedMetrics, logicalPortMetrics, "Should keep using per port status on next scrape")
+	assert.Equal(t, 5, mockLogicalPortClient.statusCalls, "Should not retry bulk status once it is unavailable")
+}
+
+func TestLogicalPortCollector_GenerateLogicalPortInfoMetrics(t *testing.T) {
+	logicalPortResponses := []mockLogicalPortResponse{
+		buildLogicalPortResponse("01", "UP", nil),
+		buildLogicalPortResponse("02", "UP", nil),
+		buildLogicalPortResponse("03", "UP", nil),
+	}
+	logicalPortResponses[0].Attachment = &manager.LogicalPortAttachment{
+		AttachmentType: "VIF",
+		Id:             "fake-vif-attachment-id-01",
+	}
+	logicalPortResponses[1].Attachment = &manager.LogicalPortAttachment{
+		AttachmentType: "LOGICALROUTER",
+		Id:             "fake-logical-router-port-id-02",
+	}
+	mockLogicalPortClient := &mockLogicalPortClient{
+		responses: logicalPortResponses,
+		vifs: []manager.VirtualNetworkInterface{
+			{
+				LportAttachmentId: "fake-vif-attachment-id-01",
+				OwnerVmId:         "fake-vm-external-id-01",
+				HostId:            fakeFabricNodeID("01"),
+			},
+		},
+		virtualMachines: []manager.VirtualMachine{
+			{
+				ExternalId:  "fake-vm-external-id-01",
+				DisplayName: "fake-vm-name-01",
+			},
+		},
+		transportNodes: []manager.TransportNode{
+			{
+				Id:          fakeTransportNodeID("01"),
+				DisplayName: fakeTransportNodeName("01"),
+				NodeId:      fakeFabricNodeID("01"),
+			},
+		},
+	}
+	expectedMetrics := []logicalPortInfoMetric{
+		{
+			ID:                "fake-logical-port-id-01",
+			Name:              "fake-logical-port-name-01",
+			LogicalSwitchID:   "fake-logical-switch-id-01",
+			AttachmentType:    "VIF",
+			AttachmentID:      "fake-vif-attachment-id-01",
+			VMName:            "fake-vm-name-01",
+			TransportNodeID:   fakeTransportNodeID("01"),
+			TransportNodeName: fakeTransportNodeName("01"),
+		}, {
+			ID:              "fake-logical-port-id-02",
+			Name:            "fake-logical-port-name-02",
+			LogicalSwitchID: "fake-logical-switch-id-02",
+			AttachmentType:  "LOGICALROUTER",
+			AttachmentID:    "fake-logical-router-port-id-02",
+		}, {
+			ID:              "fake-logical-port-id-03",
+			Name:            "fake-logical-port-name-03",
+			LogicalSwitchID: "fake-logical-switch-id-03",
+		},
+	}
+	logger := log.NewNopLogger()
+	logicalPortCollector := newLogicalPortCollector(mockLogicalPortClient, logger, nil)
+	lports, _ := logicalPortCollector.listAllLogicalPorts()
+	logicalPortMetrics := logicalPortCollector.generateLogicalPortInfoMetrics(lports)
+	assert.ElementsMatch(t, expectedMetrics, logicalPortMetrics)
+}
+
+// newFakeLogicalPortServer serves logical ports and their operational status the way NSX-T manager does.
+// The bulk status endpoint is only served through the batch API when bulkStatus is set.
+// It returns the number of API calls received, excluding session creation.
+func newFakeLogicalPortServer(lportCount int, bulkStatus bool) (*httptest.Server, *int64) {
+	const pageSize = 1000
+	var calls int64
+	var lports []manager.LogicalPort
+	for i := 0; i < lportCount; i++ {
+		lports = append(lports, manager.LogicalPort{
+			Id:              fmt.Sprintf("%s-%05d", fakeLogicalPortID, i),
+			DisplayName:     fmt.Sprintf("%s-%05d", fakeLogicalPortDisplayName, i),
+			LogicalSwitchId: fmt.Sprintf("%s-%05d", faceLogicalSwitchID, i),
+		})
+	}
+	page := func(cursor string) (int, int, string) {
+		start, _ := strconv.Atoi(cursor)
+		end := start + pageSize
+		if end >= len(lports) {
+			return start, len(lports), ""
+		}
+		return start, end, strconv.Itoa(end)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/session/create", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/api/v1/logical-ports", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		start, end, cursor := page(r.URL.Query().Get("cursor"))
+		json.NewEncoder(w).Encode(manager.LogicalPortListResult{Results: lports[start:end], Cursor: cursor})
+	})
+	mux.HandleFunc("/api/v1/logical-ports/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		lportID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/logical-ports/"), "/status")
+		json.NewEncoder(w).Encode(manager.LogicalPortOperationalStatus{LogicalPortId: lportID, Status: "UP"})
+	})
+	mux.HandleFunc("/api/v1/batch", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		var batchRequest apiservice.BatchRequest
+		json.NewDecoder(r.Body).Decode(&batchRequest)
+		uri, _ := url.Parse(batchRequest.Requests[0].Uri)
+		if !bulkStatus || uri.Path != "/v1/logical-ports/operational-status" {
+			json.NewEncoder(w).Encode(apiservice.BatchResponse{Results: []apiservice.BatchResponseItem{{Code: http.StatusNotFound}}})
+			return
+		}
+		start, end, cursor := page(uri.Query().Get("cursor"))
+		statusResult := client.LogicalPortOperationalStatusListResult{Cursor: cursor}
+		for _, lport := range lports[start:end] {
+			statusResult.Results = append(statusResult.Results, manager.LogicalPortOperationalStatus{LogicalPortId: lport.Id, Status: "UP"})
+		}
+		var body interface{} = statusResult
+		json.NewEncoder(w).Encode(apiservice.BatchResponse{Results: []apiservice.BatchResponseItem{{Code: http.StatusOK, Body: &body}}})
+	})
+	return httptest.NewServer(mux), &calls
+}
+
+func BenchmarkLogicalPortCollector_GenerateLogicalPortStatusMetrics(b *testing.B) {
+	benchmarks := []struct {
+		name       string
+		bulkStatus bool
+	}{
+		{
+			name:       "bulk",
+			bulkStatus: true,
+		}, {
+			name: "per_port",
+		},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			server, calls := newFakeLogicalPortServer(5000, bm.bulkStatus)
+			defer server.Close()
+			serverURL, _ := url.Parse(server.URL)
+			apiClient, err := nsxt.NewAPIClient(&nsxt.Configuration{
+				BasePath: "/api/v1",
+				Host:     serverURL.Host,
+				Scheme:   serverURL.Scheme,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			logger := log.NewNopLogger()
+			logicalPortCollector := newLogicalPortCollector(client.NewNSXTClient(apiClient, logger), logger, nil)
+			lports, err := logicalPortCollector.listAllLogicalPorts()
+			if err != nil {
+				b.Fatal(err)
+			}
+			atomic.StoreInt64(calls, 0)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if metrics := logicalPortCollector.generateLogicalPortStatusMetrics(lports); len(metrics) != len(lports) {
+					b.Fatalf("expected %d status metrics, got %d", len(lports), len(metrics))
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(calls))/float64(b.N), "calls/op")
+		})
+	}
+}
+
+func buildLogicalPortStatisticResponse(id string, err error) mockLogicalPortResponse {
+	return mockLogicalPortResponse{
+		ID:              fmt.Sprintf("%s-%s", fakeLogicalPortID, id),
+		DisplayName:     fmt.Sprintf("%s-%s", fakeLogicalPortDisplayName, id),
+		LogicalSwitchID: fmt.Sprintf("%s-%s", faceLogicalSwitchID, id),
+		Error:           err,
+		Statistics: manager.LogicalPortStatistics{
+			RxBytes:     &manager.DataCounter{Total: 1024},
+			RxPackets:   &manager.DataCounter{Total: 16, Dropped: 1},
+			TxBytes:     &manager.DataCounter{Total: 2048},
+			TxPackets:   &manager.DataCounter{Total: 32, Dropped: 2},
+			MacLearning: &manager.MacLearningCounters{MacsLearned: 3},
+		},
+		State: manager.LogicalPortState{
+			DiscoveredBindings: []manager.AddressBindingEntry{
+				{Binding: &manager.PacketAddressClassifier{IpAddress: "10.0.0.1", MacAddress: "00:50:56:00:00:01"}},
+				{Binding: &manager.PacketAddressClassifier{IpAddress: "10.0.0.1", MacAddress: "00:50:56:00:00:01"}},
+				{Binding: &manager.PacketAddressClassifier{IpAddress: "fe80::1", MacAddress: "00:50:56:00:00:01"}},
+				{Binding: &manager.PacketAddressClassifier{MacAddress: "00:50:56:00:00:02"}},
+			},
+		},
+	}
+}
+
+func TestLogicalPortCollector_GenerateLogicalPortStatisticMetrics(t *testing.T) {
+	expectedMetric := logicalPortStatisticMetric{
+		ID:              "fake-logical-port-id-01",
+		Name:            "fake-logical-port-name-01",
+		LogicalSwitchID: "fake-logical-switch-id-01",
+		RxBytes:         1024,
+		RxPackets:       16,
+		RxDropped:       1,
+		TxBytes:         2048,
+		TxPackets:       32,
+		TxDropped:       2,
+		LearnedMACs:     3,
+		LearnedIPs:      2,
+	}
+	testcases := []struct {
+		description          string
+		statisticsInclude    *regexp.Regexp
+		logicalPortResponses []mockLogicalPortResponse
+		expectedMetrics      []logicalPortStatisticMetric
+	}{
+		{
+			description:       "Should return statistics of logical ports matching filter",
+			statisticsInclude: regexp.MustCompile("^(?:.*-01)$"),
+			logicalPortResponses: []mockLogicalPortResponse{
+				buildLogicalPortStatisticResponse("01", nil),
+				buildLogicalPortStatisticResponse("02", nil),
+			},
+			expectedMetrics: []logicalPortStatisticMetric{expectedMetric},
+		}, {
+			description:       "Should only return logical port with valid response",
+			statisticsInclude: regexp.MustCompile(".*"),
+			logicalPortResponses: []mockLogicalPortResponse{
+				buildLogicalPortStatisticResponse("01", nil),
+				buildLogicalPortStatisticResponse("02", errors.New("error get logical port statistics")),
+			},
+			expectedMetrics: []logicalPortStatisticMetric{expectedMetric},
+		}, {
+			description:       "Should return empty metrics when statistics are disabled",
+			statisticsInclude: nil,
+			logicalPortResponses: []mockLogicalPortResponse{
+				buildLogicalPortStatisticResponse("01", nil),
+			},
+			expectedMetrics: []logicalPortStatisticMetric{},
+		}, {
+			description:       "Should return empty metrics when statistics filter is invalid",
+			statisticsInclude: compileIncludeFlag("collector.logical_port.statistics_include", "fake-logical-port-[", log.NewNopLogger()),
+			logicalPortResponses: []mockLogicalPortResponse{
+				buildLogicalPortStatisticResponse("01", nil),
+			},
+			expectedMetrics: []logicalPortStatisticMetric{},
+		},
+	}
+	for _, testcase := range testcases {
+		mockLogicalPortClient := &mockLogicalPortClient{
+			responses: testcase.logicalPortResponses,
+		}
+		logger := log.NewNopLogger()
+		logicalPortCollector := newLogicalPortCollector(mockLogicalPortClient, logger, testcase.statisticsInclude)
+		lports, _ := logicalPortCollector.listAllLogicalPorts()
+		logicalPortMetrics := logicalPortCollector.generateLogicalPortStatisticMetrics(lports)
 		assert.ElementsMatch(t, testcase.expectedMetrics, logicalPortMetrics, testcase.description)
 	}
 }
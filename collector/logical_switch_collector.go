@@ -1,6 +1,8 @@
 package collector
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 
 	"nsxt_exporter/client"
@@ -10,9 +12,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	nsxt "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/manager"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 var logicalSwitchPossibleStatus = [...]string{"SUCCESS", "PARTIAL_SUCCESS", "IN_PROGRESS", "PENDING", "FAILED", "ORPHANED"}
+var logicalSwitchPossibleAdminState = [...]string{"UP", "DOWN"}
+
+var (
+	logicalSwitchTableInclude          = kingpin.Flag("collector.logical_switch.table_include", "Regexp of logical switch names to include in MAC and VTEP table sizes. Table sizes are disabled when empty.").Default("").String()
+	logicalSwitchTablePerTransportNode = kingpin.Flag("collector.logical_switch.table_per_transport_node", "Break down logical switch MAC and VTEP table sizes by transport node.").Default("false").Bool()
+)
 
 func init() {
 	registerCollector("logical_switch", createLogicalSwitchFactory)
@@ -22,7 +31,14 @@ type logicalSwitchCollector struct {
 	logicalSwitchClient client.LogicalSwitchClient
 	logger              log.Logger
 
+	tableInclude          *regexp.Regexp
+	tablePerTransportNode bool
+
 	logicalSwitchStatus *prometheus.Desc
+	logicalSwitchInfo   *prometheus.Desc
+	adminState          *prometheus.Desc
+	logicalPorts        *prometheus.Desc
+	switchingProfile    *prometheus.Desc
 	rxByteTotal         *prometheus.Desc
 	rxByteDropped       *prometheus.Desc
 	rxPacketTotal       *prometheus.Desc
@@ -31,6 +47,8 @@ type logicalSwitchCollector struct {
 	txByteDropped       *prometheus.Desc
 	txPacketTotal       *prometheus.Desc
 	txPacketDropped     *prometheus.Desc
+	macTableEntries     *prometheus.Desc
+	vtepTableEntries    *prometheus.Desc
 }
 
 type logicalSwitchStatusMetric struct {
@@ -40,6 +58,18 @@ type logicalSwitchStatusMetric struct {
 	StatusDetail    map[string]float64
 }
 
+type logicalSwitchSummaryMetric struct {
+	ID                string
+	Name              string
+	TransportZoneID   string
+	ReplicationMode   string
+	VNI               string
+	VLAN              string
+	AdminStateDetail  map[string]float64
+	LogicalPorts      float64
+	SwitchingProfiles []manager.SwitchingProfileTypeIdEntry
+}
+
 type logicalSwitchStatisticMetric struct {
 	ID              string
 	Name            string
@@ -54,18 +84,55 @@ type logicalSwitchStatisticMetric struct {
 	TxPacketDropped float64
 }
 
+type logicalSwitchTableMetric struct {
+	ID               string
+	Name             string
+	TransportZoneID  string
+	TransportNodeID  string
+	MacTableEntries  float64
+	VtepTableEntries float64
+}
+
 func createLogicalSwitchFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
 	nsxtClient := client.NewNSXTClient(apiClient, logger)
-	return newLogicalSwitchCollector(nsxtClient, logger)
+	var tableInclude *regexp.Regexp
+	if *logicalSwitchTableInclude != "" {
+		tableInclude = compileIncludeFlag("collector.logical_switch.table_include", *logicalSwitchTableInclude, logger)
+	}
+	return newLogicalSwitchCollector(nsxtClient, logger, tableInclude, *logicalSwitchTablePerTransportNode)
 }
 
-func newLogicalSwitchCollector(lswitchClient client.LogicalSwitchClient, logger log.Logger) *logicalSwitchCollector {
+func newLogicalSwitchCollector(lswitchClient client.LogicalSwitchClient, logger log.Logger, tableInclude *regexp.Regexp, tablePerTransportNode bool) *logicalSwitchCollector {
 	logicalSwitchStatus := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "logical_switch", "status"),
 		"Status of logical switch",
 		[]string{"id", "name", "transport_zone_id", "status"},
 		nil,
 	)
+	logicalSwitchInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "info"),
+		"Info of logical switch replication mode and segment",
+		[]string{"id", "name", "transport_zone_id", "replication_mode", "vni", "vlan"},
+		nil,
+	)
+	adminState := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "admin_state"),
+		"Admin state of logical switch",
+		[]string{"id", "name", "transport_zone_id", "state"},
+		nil,
+	)
+	logicalPorts := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "logical_ports"),
+		"Number of logical ports on logical switch",
+		[]string{"id", "name", "transport_zone_id"},
+		nil,
+	)
+	switchingProfile := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "switching_profile_info"),
+		"Info of switching profile bound to logical switch",
+		[]string{"id", "name", "transport_zone_id", "switching_profile_id", "switching_profile_type"},
+		nil,
+	)
 	rxByteTotal := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "logical_switch", "rx_byte"),
 		"Total bytes received (rx) on logical switch",
@@ -114,24 +181,48 @@ func newLogicalSwitchCollector(lswitchClient client.LogicalSwitchClient, logger
 		[]string{"id", "name", "transport_zone_id"},
 		nil,
 	)
+	macTableEntries := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "mac_table_entries"),
+		"Number of entries in logical switch MAC table",
+		[]string{"id", "name", "transport_zone_id", "transport_node_id"},
+		nil,
+	)
+	vtepTableEntries := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "logical_switch", "vtep_table_entries"),
+		"Number of entries in logical switch VTEP table",
+		[]string{"id", "name", "transport_zone_id", "transport_node_id"},
+		nil,
+	)
 	return &logicalSwitchCollector{
-		logicalSwitchClient: lswitchClient,
-		logger:              logger,
-		logicalSwitchStatus: logicalSwitchStatus,
-		rxPacketTotal:       rxPacketTotal,
-		rxPacketDropped:     rxPacketDropped,
-		rxByteTotal:         rxByteTotal,
-		rxByteDropped:       rxByteDropped,
-		txPacketTotal:       txPacketTotal,
-		txPacketDropped:     txPacketDropped,
-		txByteTotal:         txByteTotal,
-		txByteDropped:       txByteDropped,
+		logicalSwitchClient:   lswitchClient,
+		logger:                logger,
+		tableInclude:          tableInclude,
+		tablePerTransportNode: tablePerTransportNode,
+		logicalSwitchStatus:   logicalSwitchStatus,
+		logicalSwitchInfo:     logicalSwitchInfo,
+		adminState:            adminState,
+		logicalPorts:          logicalPorts,
+		switchingProfile:      switchingProfile,
+		rxPacketTotal:         rxPacketTotal,
+		rxPacketDropped:       rxPacketDropped,
+		rxByteTotal:           rxByteTotal,
+		rxByteDropped:         rxByteDropped,
+		txPacketTotal:         txPacketTotal,
+		txPacketDropped:       txPacketDropped,
+		txByteTotal:           txByteTotal,
+		txByteDropped:         txByteDropped,
+		macTableEntries:       macTableEntries,
+		vtepTableEntries:      vtepTableEntries,
 	}
 }
 
 // Describe implements the prometheus.Collector interface.
 func (c *logicalSwitchCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.logicalSwitchStatus
+	ch <- c.logicalSwitchInfo
+	ch <- c.adminState
+	ch <- c.logicalPorts
+	ch <- c.switchingProfile
 	ch <- c.rxByteTotal
 	ch <- c.rxByteDropped
 	ch <- c.rxPacketTotal
@@ -140,6 +231,8 @@ func (c *logicalSwitchCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.txByteDropped
 	ch <- c.txPacketTotal
 	ch <- c.txPacketDropped
+	ch <- c.macTableEntries
+	ch <- c.vtepTableEntries
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -156,6 +249,25 @@ func (c *logicalSwitchCollector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.MustNewConstMetric(c.logicalSwitchStatus, prometheus.GaugeValue, value, labels...)
 		}
 	}
+	lports, err := c.logicalSwitchClient.ListAllLogicalPorts()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list logical ports", "err", err)
+	}
+	lportsListed := err == nil
+	lswitchSummaryMetrics := c.generateLogicalSwitchSummaryMetrics(logicalSwitches, lports)
+	for _, m := range lswitchSummaryMetrics {
+		labels := []string{m.ID, m.Name, m.TransportZoneID}
+		ch <- prometheus.MustNewConstMetric(c.logicalSwitchInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.TransportZoneID, m.ReplicationMode, m.VNI, m.VLAN)
+		for state, value := range m.AdminStateDetail {
+			ch <- prometheus.MustNewConstMetric(c.adminState, prometheus.GaugeValue, value, m.ID, m.Name, m.TransportZoneID, state)
+		}
+		if lportsListed {
+			ch <- prometheus.MustNewConstMetric(c.logicalPorts, prometheus.GaugeValue, m.LogicalPorts, labels...)
+		}
+		for _, profile := range m.SwitchingProfiles {
+			ch <- prometheus.MustNewConstMetric(c.switchingProfile, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.TransportZoneID, profile.Value, profile.Key)
+		}
+	}
 	lswitchStatisticMetrics := c.generateLogicalSwitchStatisticMetrics(logicalSwitches)
 	for _, metric := range lswitchStatisticMetrics {
 		labels := []string{metric.ID, metric.Name, metric.TransportZoneID}
@@ -168,6 +280,23 @@ func (c *logicalSwitchCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(c.txPacketTotal, prometheus.GaugeValue, metric.TxPacketTotal, labels...)
 		ch <- prometheus.MustNewConstMetric(c.txPacketDropped, prometheus.GaugeValue, metric.TxPacketDropped, labels...)
 	}
+	if c.tableInclude == nil {
+		return
+	}
+	var transportNodes []manager.TransportNode
+	if c.tablePerTransportNode {
+		transportNodes, err = c.logicalSwitchClient.ListAllTransportNodes()
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to list transport nodes", "err", err)
+			return
+		}
+	}
+	lswitchTableMetrics := c.generateLogicalSwitchTableMetrics(logicalSwitches, transportNodes)
+	for _, metric := range lswitchTableMetrics {
+		labels := []string{metric.ID, metric.Name, metric.TransportZoneID, metric.TransportNodeID}
+		ch <- prometheus.MustNewConstMetric(c.macTableEntries, prometheus.GaugeValue, metric.MacTableEntries, labels...)
+		ch <- prometheus.MustNewConstMetric(c.vtepTableEntries, prometheus.GaugeValue, metric.VtepTableEntries, labels...)
+	}
 }
 
 func (c *logicalSwitchCollector) generateLogicalSwitchStatusMetrics(logicalSwitches []manager.LogicalSwitch) (logicalSwitchStatusMetrics []logicalSwitchStatusMetric) {
@@ -195,6 +324,42 @@ func (c *logicalSwitchCollector) generateLogicalSwitchStatusMetrics(logicalSwitc
 	return
 }
 
+func (c *logicalSwitchCollector) generateLogicalSwitchSummaryMetrics(logicalSwitches []manager.LogicalSwitch, lports []manager.LogicalPort) (logicalSwitchSummaryMetrics []logicalSwitchSummaryMetric) {
+	lportCounts := make(map[string]float64)
+	for _, lport := range lports {
+		lportCounts[lport.LogicalSwitchId]++
+	}
+	for _, logicalSwitch := range logicalSwitches {
+		logicalSwitchSummaryMetric := logicalSwitchSummaryMetric{
+			ID:                logicalSwitch.Id,
+			Name:              logicalSwitch.DisplayName,
+			TransportZoneID:   logicalSwitch.TransportZoneId,
+			ReplicationMode:   logicalSwitch.ReplicationMode,
+			AdminStateDetail:  map[string]float64{},
+			LogicalPorts:      lportCounts[logicalSwitch.Id],
+			SwitchingProfiles: logicalSwitch.SwitchingProfileIds,
+		}
+		// Overlay logical switches are identified by VNI, VLAN backed logical switches by VLAN ID.
+		// Replication mode only applies to overlay, so it marks them even when the VNI is not reported.
+		if logicalSwitch.Vni != 0 || logicalSwitch.ReplicationMode != "" {
+			if logicalSwitch.Vni != 0 {
+				logicalSwitchSummaryMetric.VNI = strconv.Itoa(int(logicalSwitch.Vni))
+			}
+		} else {
+			logicalSwitchSummaryMetric.VLAN = strconv.FormatInt(logicalSwitch.Vlan, 10)
+		}
+		for _, possibleState := range logicalSwitchPossibleAdminState {
+			stateValue := 0.0
+			if possibleState == strings.ToUpper(logicalSwitch.AdminState) {
+				stateValue = 1.0
+			}
+			logicalSwitchSummaryMetric.AdminStateDetail[possibleState] = stateValue
+		}
+		logicalSwitchSummaryMetrics = append(logicalSwitchSummaryMetrics, logicalSwitchSummaryMetric)
+	}
+	return
+}
+
 func (c *logicalSwitchCollector) generateLogicalSwitchStatisticMetrics(logicalSwitches []manager.LogicalSwitch) (logicalSwitchStatisticMetrics []logicalSwitchStatisticMetric) {
 	for _, logicalSwitch := range logicalSwitches {
 		logicalSwitchStatistic, err := c.logicalSwitchClient.GetLogicalSwitchStatistic(logicalSwitch.Id)
@@ -219,3 +384,55 @@ func (c *logicalSwitchCollector) generateLogicalSwitchStatisticMetrics(logicalSw
 	}
 	return
 }
+
+// generateLogicalSwitchTableMetrics reads MAC and VTEP tables of logical switches matching the table filter.
+// Tables are read from the central control plane, or from every transport node in the switch transport zone
+// when the collector breaks down table sizes by transport node.
+func (c *logicalSwitchCollector) generateLogicalSwitchTableMetrics(logicalSwitches []manager.LogicalSwitch, transportNodes []manager.TransportNode) (logicalSwitchTableMetrics []logicalSwitchTableMetric) {
+	if c.tableInclude == nil {
+		return
+	}
+	for _, logicalSwitch := range logicalSwitches {
+		if !c.tableInclude.MatchString(logicalSwitch.DisplayName) {
+			continue
+		}
+		transportNodeIDs := []string{""}
+		if c.tablePerTransportNode {
+			transportNodeIDs = transportNodeIDsInTransportZone(transportNodes, logicalSwitch.TransportZoneId)
+		}
+		for _, transportNodeID := range transportNodeIDs {
+			macTableEntries, err := c.logicalSwitchClient.ListAllLogicalSwitchMacTableEntries(logicalSwitch.Id, transportNodeID)
+			if err != nil {
+				level.Error(c.logger).Log("msg", "Unable to get logical switch MAC table", "id", logicalSwitch.Id, "transport_node_id", transportNodeID, "err", err)
+				continue
+			}
+			vtepTableEntries, err := c.logicalSwitchClient.ListAllLogicalSwitchVtepTableEntries(logicalSwitch.Id, transportNodeID)
+			if err != nil {
+				level.Error(c.logger).Log("msg", "Unable to get logical switch VTEP table", "id", logicalSwitch.Id, "transport_node_id", transportNodeID, "err", err)
+				continue
+			}
+			logicalSwitchTableMetric := logicalSwitchTableMetric{
+				ID:               logicalSwitch.Id,
+				Name:             logicalSwitch.DisplayName,
+				TransportZoneID:  logicalSwitch.TransportZoneId,
+				TransportNodeID:  transportNodeID,
+				MacTableEntries:  float64(len(macTableEntries)),
+				VtepTableEntries: float64(len(vtepTableEntries)),
+			}
+			logicalSwitchTableMetrics = append(logicalSwitchTableMetrics, logicalSwitchTableMetric)
+		}
+	}
+	return
+}
+
+func transportNodeIDsInTransportZone(transportNodes []manager.TransportNode, transportZoneID string) (transportNodeIDs []string) {
+	for _, transportNode := range transportNodes {
+		for _, endpoint := range transportNode.TransportZoneEndpoints {
+			if endpoint.TransportZoneId == transportZoneID {
+				transportNodeIDs = append(transportNodeIDs, transportNode.Id)
+				break
+			}
+		}
+	}
+	return
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/go-kit/kit/log"
@@ -16,7 +17,16 @@ const (
 )
 
 type mockLogicalSwitchClient struct {
-	responses []mockLogicalSwitchResponse
+	responses      []mockLogicalSwitchResponse
+	tableResponses []mockLogicalSwitchTableResponse
+}
+
+type mockLogicalSwitchTableResponse struct {
+	LogicalSwitchID  string
+	TransportNodeID  string
+	MacTableEntries  []manager.MacTableEntry
+	VtepTableEntries []manager.VtepTableEntry
+	Error            error
 }
 
 type mockLogicalSwitchResponse struct {
@@ -59,6 +69,32 @@ func (c *mockLogicalSwitchClient) GetLogicalSwitchStatistic(lswitchID string) (m
 	panic("implement me")
 }
 
+func (c *mockLogicalSwitchClient) ListAllLogicalSwitchMacTableEntries(lswitchID string, transportNodeID string) ([]manager.MacTableEntry, error) {
+	for _, res := range c.tableResponses {
+		if res.LogicalSwitchID == lswitchID && res.TransportNodeID == transportNodeID {
+			return res.MacTableEntries, res.Error
+		}
+	}
+	return nil, errors.New("logical switch MAC table not found")
+}
+
+func (c *mockLogicalSwitchClient) ListAllLogicalSwitchVtepTableEntries(lswitchID string, transportNodeID string) ([]manager.VtepTableEntry, error) {
+	for _, res := range c.tableResponses {
+		if res.LogicalSwitchID == lswitchID && res.TransportNodeID == transportNodeID {
+			return res.VtepTableEntries, res.Error
+		}
+	}
+	return nil, errors.New("logical switch VTEP table not found")
+}
+
+func (c *mockLogicalSwitchClient) ListAllTransportNodes() ([]manager.TransportNode, error) {
+	panic("unused function. Only used to satisfy LogicalSwitchClient interface")
+}
+
+func (c *mockLogicalSwitchClient) ListAllLogicalPorts() ([]manager.LogicalPort, error) {
+	panic("unused function. Only used to satisfy LogicalSwitchClient interface")
+}
+
 func buildLogicalSwitchStatusResponse(id string, status string, err error) mockLogicalSwitchResponse {
 	return mockLogicalSwitchResponse{
 		logicalSwitch: manager.LogicalSwitch{
@@ -191,7 +227,7 @@ func TestLogicalSwitchCollector_GenerateLogicalSwitchStatusMetrics(t *testing.T)
 			responses: tc.lswitchResponses,
 		}
 		logger := log.NewNopLogger()
-		lswitchCollector := newLogicalSwitchCollector(mockLogicalSwitchClient, logger)
+		lswitchCollector := newLogicalSwitchCollector(mockLogicalSwitchClient, logger, nil, false)
 		var logicalSwitches []manager.LogicalSwitch
 		for _, res := range tc.lswitchResponses {
 			logicalSwitches = append(logicalSwitches, res.logicalSwitch)
@@ -201,6 +237,84 @@ func TestLogicalSwitchCollector_GenerateLogicalSwitchStatusMetrics(t *testing.T)
 	}
 }
 
+func TestLogicalSwitchCollector_GenerateLogicalSwitchSummaryMetrics(t *testing.T) {
+	logicalSwitches := []manager.LogicalSwitch{
+		{
+			Id:              fakeLogicalSwitchID + "-01",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-01",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-01",
+			AdminState:      "UP",
+			ReplicationMode: "MTEP",
+			Vni:             5001,
+			SwitchingProfileIds: []manager.SwitchingProfileTypeIdEntry{
+				{Key: "SpoofGuardSwitchingProfile", Value: "fake-spoofguard-profile-id"},
+				{Key: "QosSwitchingProfile", Value: "fake-qos-profile-id"},
+			},
+		}, {
+			Id:              fakeLogicalSwitchID + "-02",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-02",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-02",
+			AdminState:      "down",
+			Vlan:            100,
+		}, {
+			Id:              fakeLogicalSwitchID + "-03",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-03",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-03",
+			AdminState:      "UP",
+			ReplicationMode: "SOURCE",
+		}, {
+			Id:              fakeLogicalSwitchID + "-04",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-04",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-04",
+			AdminState:      "UP",
+			Vlan:            0,
+		},
+	}
+	lports := []manager.LogicalPort{
+		{LogicalSwitchId: fakeLogicalSwitchID + "-01"},
+		{LogicalSwitchId: fakeLogicalSwitchID + "-01"},
+		{LogicalSwitchId: fakeLogicalSwitchID + "-03"},
+	}
+	expectedMetrics := []logicalSwitchSummaryMetric{
+		{
+			ID:               fakeLogicalSwitchID + "-01",
+			Name:             fakeLogicalSwitchDisplayName + "-01",
+			TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-01",
+			ReplicationMode:  "MTEP",
+			VNI:              "5001",
+			AdminStateDetail: map[string]float64{"UP": 1.0, "DOWN": 0.0},
+			LogicalPorts:     2,
+			SwitchingProfiles: []manager.SwitchingProfileTypeIdEntry{
+				{Key: "SpoofGuardSwitchingProfile", Value: "fake-spoofguard-profile-id"},
+				{Key: "QosSwitchingProfile", Value: "fake-qos-profile-id"},
+			},
+		}, {
+			ID:               fakeLogicalSwitchID + "-02",
+			Name:             fakeLogicalSwitchDisplayName + "-02",
+			TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-02",
+			VLAN:             "100",
+			AdminStateDetail: map[string]float64{"UP": 0.0, "DOWN": 1.0},
+		}, {
+			ID:               fakeLogicalSwitchID + "-03",
+			Name:             fakeLogicalSwitchDisplayName + "-03",
+			TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-03",
+			ReplicationMode:  "SOURCE",
+			AdminStateDetail: map[string]float64{"UP": 1.0, "DOWN": 0.0},
+			LogicalPorts:     1,
+		}, {
+			ID:               fakeLogicalSwitchID + "-04",
+			Name:             fakeLogicalSwitchDisplayName + "-04",
+			TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-04",
+			VLAN:             "0",
+			AdminStateDetail: map[string]float64{"UP": 1.0, "DOWN": 0.0},
+		},
+	}
+	logger := log.NewNopLogger()
+	lswitchCollector := newLogicalSwitchCollector(&mockLogicalSwitchClient{}, logger, nil, false)
+	metrics := lswitchCollector.generateLogicalSwitchSummaryMetrics(logicalSwitches, lports)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
+
 func TestLogicalSwitchCollector_GenerateLogicalSwitchStatisticsMetrics(t *testing.T) {
 	testcases := []struct {
 		description      string
@@ -272,7 +386,7 @@ func TestLogicalSwitchCollector_GenerateLogicalSwitchStatisticsMetrics(t *testin
 			responses: tc.lswitchResponses,
 		}
 		logger := log.NewNopLogger()
-		lswitchCollector := newLogicalSwitchCollector(mockLogicalSwitchClient, logger)
+		lswitchCollector := newLogicalSwitchCollector(mockLogicalSwitchClient, logger, nil, false)
 		var logicalSwitches []manager.LogicalSwitch
 		for _, res := range tc.lswitchResponses {
 			logicalSwitches = append(logicalSwitches, res.logicalSwitch)
@@ -281,3 +395,107 @@ func TestLogicalSwitchCollector_GenerateLogicalSwitchStatisticsMetrics(t *testin
 		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
 	}
 }
+
+func TestLogicalSwitchCollector_GenerateLogicalSwitchTableMetrics(t *testing.T) {
+	logicalSwitches := []manager.LogicalSwitch{
+		{
+			Id:              fakeLogicalSwitchID + "-01",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-01",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-01",
+		}, {
+			Id:              fakeLogicalSwitchID + "-02",
+			DisplayName:     fakeLogicalSwitchDisplayName + "-02",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-01",
+		}, {
+			Id:              fakeLogicalSwitchID + "-03",
+			DisplayName:     "excluded-logical-switch",
+			TransportZoneId: fakeLogicalSwitchTransportZoneID + "-01",
+		},
+	}
+	transportNodes := []manager.TransportNode{
+		{
+			Id: fakeTransportNodeID("01"),
+			TransportZoneEndpoints: []manager.TransportZoneEndPoint{
+				{TransportZoneId: fakeLogicalSwitchTransportZoneID + "-01"},
+			},
+		}, {
+			Id: fakeTransportNodeID("02"),
+			TransportZoneEndpoints: []manager.TransportZoneEndPoint{
+				{TransportZoneId: fakeLogicalSwitchTransportZoneID + "-02"},
+			},
+		},
+	}
+	tableResponses := []mockLogicalSwitchTableResponse{
+		{
+			LogicalSwitchID:  fakeLogicalSwitchID + "-01",
+			MacTableEntries:  []manager.MacTableEntry{{MacAddress: "00:50:56:00:00:01"}, {MacAddress: "00:50:56:00:00:02"}},
+			VtepTableEntries: []manager.VtepTableEntry{{VtepIp: "192.168.0.1"}},
+		}, {
+			LogicalSwitchID: fakeLogicalSwitchID + "-02",
+			Error:           errors.New("error getting logical switch table"),
+		}, {
+			LogicalSwitchID:  fakeLogicalSwitchID + "-01",
+			TransportNodeID:  fakeTransportNodeID("01"),
+			MacTableEntries:  []manager.MacTableEntry{{MacAddress: "00:50:56:00:00:01"}},
+			VtepTableEntries: []manager.VtepTableEntry{{VtepIp: "192.168.0.1"}, {VtepIp: "192.168.0.2"}},
+		}, {
+			LogicalSwitchID: fakeLogicalSwitchID + "-02",
+			TransportNodeID: fakeTransportNodeID("01"),
+		},
+	}
+	testcases := []struct {
+		description           string
+		tableInclude          *regexp.Regexp
+		tablePerTransportNode bool
+		expectedMetrics       []logicalSwitchTableMetric
+	}{
+		{
+			description:     "Should not return table metrics without table filter",
+			expectedMetrics: []logicalSwitchTableMetric{},
+		}, {
+			description:     "Should not return table metrics when table filter is invalid",
+			tableInclude:    compileIncludeFlag("collector.logical_switch.table_include", fakeLogicalSwitchDisplayName+"-[", log.NewNopLogger()),
+			expectedMetrics: []logicalSwitchTableMetric{},
+		}, {
+			description:  "Should return table sizes of included logical switches from central control plane",
+			tableInclude: regexp.MustCompile("^(?:" + fakeLogicalSwitchDisplayName + "-.*)$"),
+			expectedMetrics: []logicalSwitchTableMetric{
+				{
+					ID:               fakeLogicalSwitchID + "-01",
+					Name:             fakeLogicalSwitchDisplayName + "-01",
+					TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-01",
+					MacTableEntries:  2,
+					VtepTableEntries: 1,
+				},
+			},
+		}, {
+			description:           "Should return table sizes per transport node in logical switch transport zone",
+			tableInclude:          regexp.MustCompile("^(?:" + fakeLogicalSwitchDisplayName + "-.*)$"),
+			tablePerTransportNode: true,
+			expectedMetrics: []logicalSwitchTableMetric{
+				{
+					ID:               fakeLogicalSwitchID + "-01",
+					Name:             fakeLogicalSwitchDisplayName + "-01",
+					TransportZoneID:  fakeLogicalSwitchTransportZoneID + "-01",
+					TransportNodeID:  fakeTransportNodeID("01"),
+					MacTableEntries:  1,
+					VtepTableEntries: 2,
+				}, {
+					ID:              fakeLogicalSwitchID + "-02",
+					Name:            fakeLogicalSwitchDisplayName + "-02",
+					TransportZoneID: fakeLogicalSwitchTransportZoneID + "-01",
+					TransportNodeID: fakeTransportNodeID("01"),
+				},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		mockLogicalSwitchClient := &mockLogicalSwitchClient{
+			tableResponses: tableResponses,
+		}
+		logger := log.NewNopLogger()
+		lswitchCollector := newLogicalSwitchCollector(mockLogicalSwitchClient, logger, tc.tableInclude, tc.tablePerTransportNode)
+		metrics := lswitchCollector.generateLogicalSwitchTableMetrics(logicalSwitches, transportNodes)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
@@ -0,0 +1,285 @@
+package collector
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func init() {
+	registerCollector("pool_management", createPoolManagementCollectorFactory)
+}
+
+type poolManagementCollector struct {
+	poolManagementClient client.PoolManagementClient
+	logger               log.Logger
+
+	ipPoolTotal           *prometheus.Desc
+	ipPoolAllocated       *prometheus.Desc
+	ipPoolFree            *prometheus.Desc
+	ipPoolSubnetTotal     *prometheus.Desc
+	ipPoolSubnetAllocated *prometheus.Desc
+	ipPoolSubnetFree      *prometheus.Desc
+	ipBlockSize           *prometheus.Desc
+	ipBlockSubnets        *prometheus.Desc
+	ipBlockFree           *prometheus.Desc
+}
+
+type ipPoolMetric struct {
+	ID        string
+	Name      string
+	Total     float64
+	Allocated float64
+	Free      float64
+	Subnets   []ipPoolSubnetMetric
+}
+
+type ipPoolSubnetMetric struct {
+	CIDR      string
+	Total     float64
+	Allocated float64
+	Free      float64
+}
+
+type ipBlockMetric struct {
+	ID      string
+	Name    string
+	CIDR    string
+	Size    float64
+	Subnets float64
+	Free    float64
+}
+
+func createPoolManagementCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newPoolManagementCollector(nsxtClient, logger)
+}
+
+func newPoolManagementCollector(poolManagementClient client.PoolManagementClient, logger log.Logger) *poolManagementCollector {
+	ipPoolTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "total_ips"),
+		"Total number of IPs in IP pool",
+		[]string{"id", "name"},
+		nil,
+	)
+	ipPoolAllocated := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "allocated_ips"),
+		"Number of allocated IPs in IP pool",
+		[]string{"id", "name"},
+		nil,
+	)
+	ipPoolFree := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "free_ips"),
+		"Number of free IPs in IP pool",
+		[]string{"id", "name"},
+		nil,
+	)
+	ipPoolSubnetTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "subnet_total_ips"),
+		"Total number of IPs in allocation ranges of IP pool subnet",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	ipPoolSubnetAllocated := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "subnet_allocated_ips"),
+		"Number of allocated IPs in IP pool subnet",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	ipPoolSubnetFree := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_pool", "subnet_free_ips"),
+		"Number of free IPs in IP pool subnet",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	ipBlockSize := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_block", "size_ips"),
+		"Total number of IPs in IP block",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	ipBlockSubnets := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_block", "subnets"),
+		"Number of subnets carved out of IP block",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	ipBlockFree := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ip_block", "free_ips"),
+		"Number of IPs in IP block not used by any subnet",
+		[]string{"id", "name", "cidr"},
+		nil,
+	)
+	return &poolManagementCollector{
+		poolManagementClient: poolManagementClient,
+		logger:               logger,
+
+		ipPoolTotal:           ipPoolTotal,
+		ipPoolAllocated:       ipPoolAllocated,
+		ipPoolFree:            ipPoolFree,
+		ipPoolSubnetTotal:     ipPoolSubnetTotal,
+		ipPoolSubnetAllocated: ipPoolSubnetAllocated,
+		ipPoolSubnetFree:      ipPoolSubnetFree,
+		ipBlockSize:           ipBlockSize,
+		ipBlockSubnets:        ipBlockSubnets,
+		ipBlockFree:           ipBlockFree,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *poolManagementCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ipPoolTotal
+	ch <- c.ipPoolAllocated
+	ch <- c.ipPoolFree
+	ch <- c.ipPoolSubnetTotal
+	ch <- c.ipPoolSubnetAllocated
+	ch <- c.ipPoolSubnetFree
+	ch <- c.ipBlockSize
+	ch <- c.ipBlockSubnets
+	ch <- c.ipBlockFree
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *poolManagementCollector) Collect(ch chan<- prometheus.Metric) {
+	ipPools, err := c.poolManagementClient.ListAllIPPools()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IP pools", "err", err)
+	} else {
+		for _, m := range c.generateIPPoolMetrics(ipPools) {
+			ch <- prometheus.MustNewConstMetric(c.ipPoolTotal, prometheus.GaugeValue, m.Total, m.ID, m.Name)
+			ch <- prometheus.MustNewConstMetric(c.ipPoolAllocated, prometheus.GaugeValue, m.Allocated, m.ID, m.Name)
+			ch <- prometheus.MustNewConstMetric(c.ipPoolFree, prometheus.GaugeValue, m.Free, m.ID, m.Name)
+			for _, subnet := range m.Subnets {
+				ch <- prometheus.MustNewConstMetric(c.ipPoolSubnetTotal, prometheus.GaugeValue, subnet.Total, m.ID, m.Name, subnet.CIDR)
+				ch <- prometheus.MustNewConstMetric(c.ipPoolSubnetAllocated, prometheus.GaugeValue, subnet.Allocated, m.ID, m.Name, subnet.CIDR)
+				ch <- prometheus.MustNewConstMetric(c.ipPoolSubnetFree, prometheus.GaugeValue, subnet.Free, m.ID, m.Name, subnet.CIDR)
+			}
+		}
+	}
+	ipBlocks, err := c.poolManagementClient.ListAllIPBlocks()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IP blocks", "err", err)
+		return
+	}
+	ipBlockSubnets, err := c.poolManagementClient.ListAllIPBlockSubnets()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list IP block subnets", "err", err)
+		return
+	}
+	for _, m := range c.generateIPBlockMetrics(ipBlocks, ipBlockSubnets) {
+		ch <- prometheus.MustNewConstMetric(c.ipBlockSize, prometheus.GaugeValue, m.Size, m.ID, m.Name, m.CIDR)
+		ch <- prometheus.MustNewConstMetric(c.ipBlockSubnets, prometheus.GaugeValue, m.Subnets, m.ID, m.Name, m.CIDR)
+		ch <- prometheus.MustNewConstMetric(c.ipBlockFree, prometheus.GaugeValue, m.Free, m.ID, m.Name, m.CIDR)
+	}
+}
+
+func (c *poolManagementCollector) generateIPPoolMetrics(ipPools []manager.IpPool) (ipPoolMetrics []ipPoolMetric) {
+	for _, ipPool := range ipPools {
+		ipPoolMetric := ipPoolMetric{
+			ID:   ipPool.Id,
+			Name: ipPool.DisplayName,
+		}
+		if ipPool.PoolUsage != nil {
+			ipPoolMetric.Total = float64(ipPool.PoolUsage.TotalIds)
+			ipPoolMetric.Allocated = float64(ipPool.PoolUsage.AllocatedIds)
+			ipPoolMetric.Free = float64(ipPool.PoolUsage.FreeIds)
+		}
+		allocations, err := c.poolManagementClient.ListIPPoolAllocations(ipPool.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to list IP pool allocations", "id", ipPool.Id, "err", err)
+			ipPoolMetrics = append(ipPoolMetrics, ipPoolMetric)
+			continue
+		}
+		for _, subnet := range ipPool.Subnets {
+			ipPoolSubnetMetric := ipPoolSubnetMetric{
+				CIDR: subnet.Cidr,
+			}
+			for _, allocationRange := range subnet.AllocationRanges {
+				ipPoolSubnetMetric.Total += ipRangeSize(allocationRange)
+			}
+			for _, allocation := range allocations {
+				ip := net.ParseIP(allocation.AllocationId)
+				for _, allocationRange := range subnet.AllocationRanges {
+					if ipRangeContains(allocationRange, ip) {
+						ipPoolSubnetMetric.Allocated++
+						break
+					}
+				}
+			}
+			ipPoolSubnetMetric.Free = ipPoolSubnetMetric.Total - ipPoolSubnetMetric.Allocated
+			ipPoolMetric.Subnets = append(ipPoolMetric.Subnets, ipPoolSubnetMetric)
+		}
+		ipPoolMetrics = append(ipPoolMetrics, ipPoolMetric)
+	}
+	return
+}
+
+func (c *poolManagementCollector) generateIPBlockMetrics(ipBlocks []manager.IpBlock, ipBlockSubnets []manager.IpBlockSubnet) (ipBlockMetrics []ipBlockMetric) {
+	subnetsByBlock := make(map[string][]manager.IpBlockSubnet)
+	for _, subnet := range ipBlockSubnets {
+		subnetsByBlock[subnet.BlockId] = append(subnetsByBlock[subnet.BlockId], subnet)
+	}
+	for _, ipBlock := range ipBlocks {
+		size, err := cidrSize(ipBlock.Cidr)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to parse IP block cidr", "id", ipBlock.Id, "err", err)
+			continue
+		}
+		ipBlockMetric := ipBlockMetric{
+			ID:      ipBlock.Id,
+			Name:    ipBlock.DisplayName,
+			CIDR:    ipBlock.Cidr,
+			Size:    size,
+			Subnets: float64(len(subnetsByBlock[ipBlock.Id])),
+			Free:    size,
+		}
+		for _, subnet := range subnetsByBlock[ipBlock.Id] {
+			ipBlockMetric.Free -= float64(subnet.Size)
+		}
+		ipBlockMetrics = append(ipBlockMetrics, ipBlockMetric)
+	}
+	return
+}
+
+// ipRangeSize returns the number of IPs between start and end of the range, inclusive.
+func ipRangeSize(ipRange manager.IpPoolRange) float64 {
+	start := net.ParseIP(ipRange.Start)
+	end := net.ParseIP(ipRange.End)
+	if start == nil || end == nil {
+		return 0
+	}
+	size := new(big.Int).Sub(new(big.Int).SetBytes(end.To16()), new(big.Int).SetBytes(start.To16()))
+	if size.Sign() < 0 {
+		return 0
+	}
+	sizeFloat, _ := new(big.Float).SetInt(size.Add(size, big.NewInt(1))).Float64()
+	return sizeFloat
+}
+
+func ipRangeContains(ipRange manager.IpPoolRange, ip net.IP) bool {
+	start := net.ParseIP(ipRange.Start)
+	end := net.ParseIP(ipRange.End)
+	if ip == nil || start == nil || end == nil {
+		return false
+	}
+	return bytes.Compare(ip.To16(), start.To16()) >= 0 && bytes.Compare(ip.To16(), end.To16()) <= 0
+}
+
+func cidrSize(cidr string) (float64, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cidr %q: %v", cidr, err)
+	}
+	ones, bits := ipNet.Mask.Size()
+	return math.Pow(2, float64(bits-ones)), nil
+}
@@ -0,0 +1,228 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func fakeIPPoolID(id string) string {
+	return "fake-ip-pool-id-" + id
+}
+
+func fakeIPPoolName(id string) string {
+	return "fake-ip-pool-name-" + id
+}
+
+func fakeIPBlockID(id string) string {
+	return "fake-ip-block-id-" + id
+}
+
+func fakeIPBlockName(id string) string {
+	return "fake-ip-block-name-" + id
+}
+
+type ipPoolAllocationsResponse struct {
+	PoolID      string
+	Allocations []manager.AllocationIpAddress
+	Error       error
+}
+
+type mockPoolManagementClient struct {
+	ipPoolAllocationsResponses []ipPoolAllocationsResponse
+}
+
+func (c *mockPoolManagementClient) ListAllIPPools() ([]manager.IpPool, error) {
+	panic("unused function. Only used to satisfy PoolManagementClient interface")
+}
+
+func (c *mockPoolManagementClient) ListIPPoolAllocations(poolID string) ([]manager.AllocationIpAddress, error) {
+	for _, res := range c.ipPoolAllocationsResponses {
+		if res.PoolID == poolID {
+			return res.Allocations, res.Error
+		}
+	}
+	return nil, errors.New("ip pool allocations not found")
+}
+
+func (c *mockPoolManagementClient) ListAllIPBlocks() ([]manager.IpBlock, error) {
+	panic("unused function. Only used to satisfy PoolManagementClient interface")
+}
+
+func (c *mockPoolManagementClient) ListAllIPBlockSubnets() ([]manager.IpBlockSubnet, error) {
+	panic("unused function. Only used to satisfy PoolManagementClient interface")
+}
+
+func TestPoolManagementCollector_GenerateIPPoolMetrics(t *testing.T) {
+	ipPools := []manager.IpPool{
+		{
+			Id:          fakeIPPoolID("01"),
+			DisplayName: fakeIPPoolName("01"),
+			PoolUsage: &manager.PoolUsage{
+				TotalIds:     60,
+				AllocatedIds: 3,
+				FreeIds:      57,
+			},
+			Subnets: []manager.IpPoolSubnet{
+				{
+					Cidr: "10.0.0.0/24",
+					AllocationRanges: []manager.IpPoolRange{
+						{Start: "10.0.0.10", End: "10.0.0.19"},
+						{Start: "10.0.0.100", End: "10.0.0.119"},
+					},
+				}, {
+					Cidr: "10.0.1.0/24",
+					AllocationRanges: []manager.IpPoolRange{
+						{Start: "10.0.1.1", End: "10.0.1.30"},
+					},
+				},
+			},
+		}, {
+			Id:          fakeIPPoolID("02"),
+			DisplayName: fakeIPPoolName("02"),
+			PoolUsage: &manager.PoolUsage{
+				TotalIds:     256,
+				AllocatedIds: 1,
+				FreeIds:      255,
+			},
+			Subnets: []manager.IpPoolSubnet{
+				{
+					Cidr: "fd00::/120",
+					AllocationRanges: []manager.IpPoolRange{
+						{Start: "fd00::", End: "fd00::ff"},
+					},
+				},
+			},
+		},
+	}
+	testcases := []struct {
+		description                string
+		ipPoolAllocationsResponses []ipPoolAllocationsResponse
+		expectedMetrics            []ipPoolMetric
+	}{
+		{
+			description: "Should return pool and per subnet usage",
+			ipPoolAllocationsResponses: []ipPoolAllocationsResponse{
+				{
+					PoolID: fakeIPPoolID("01"),
+					Allocations: []manager.AllocationIpAddress{
+						{AllocationId: "10.0.0.10"},
+						{AllocationId: "10.0.0.119"},
+						{AllocationId: "10.0.1.1"},
+					},
+				}, {
+					PoolID: fakeIPPoolID("02"),
+					Allocations: []manager.AllocationIpAddress{
+						{AllocationId: "fd00::1"},
+					},
+				},
+			},
+			expectedMetrics: []ipPoolMetric{
+				{
+					ID:        fakeIPPoolID("01"),
+					Name:      fakeIPPoolName("01"),
+					Total:     60,
+					Allocated: 3,
+					Free:      57,
+					Subnets: []ipPoolSubnetMetric{
+						{CIDR: "10.0.0.0/24", Total: 30, Allocated: 2, Free: 28},
+						{CIDR: "10.0.1.0/24", Total: 30, Allocated: 1, Free: 29},
+					},
+				}, {
+					ID:        fakeIPPoolID("02"),
+					Name:      fakeIPPoolName("02"),
+					Total:     256,
+					Allocated: 1,
+					Free:      255,
+					Subnets: []ipPoolSubnetMetric{
+						{CIDR: "fd00::/120", Total: 256, Allocated: 1, Free: 255},
+					},
+				},
+			},
+		}, {
+			description: "Should return pool usage without subnets when allocations are unavailable",
+			ipPoolAllocationsResponses: []ipPoolAllocationsResponse{
+				{
+					PoolID: fakeIPPoolID("01"),
+					Error:  errors.New("error listing ip pool allocations"),
+				}, {
+					PoolID: fakeIPPoolID("02"),
+				},
+			},
+			expectedMetrics: []ipPoolMetric{
+				{
+					ID:        fakeIPPoolID("01"),
+					Name:      fakeIPPoolName("01"),
+					Total:     60,
+					Allocated: 3,
+					Free:      57,
+				}, {
+					ID:        fakeIPPoolID("02"),
+					Name:      fakeIPPoolName("02"),
+					Total:     256,
+					Allocated: 1,
+					Free:      255,
+					Subnets: []ipPoolSubnetMetric{
+						{CIDR: "fd00::/120", Total: 256, Allocated: 0, Free: 256},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockPoolManagementClient{
+			ipPoolAllocationsResponses: tc.ipPoolAllocationsResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newPoolManagementCollector(mockClient, logger)
+		metrics := collector.generateIPPoolMetrics(ipPools)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
+
+func TestPoolManagementCollector_GenerateIPBlockMetrics(t *testing.T) {
+	ipBlocks := []manager.IpBlock{
+		{
+			Id:          fakeIPBlockID("01"),
+			DisplayName: fakeIPBlockName("01"),
+			Cidr:        "172.16.0.0/16",
+		}, {
+			Id:          fakeIPBlockID("02"),
+			DisplayName: fakeIPBlockName("02"),
+			Cidr:        "172.17.0.0/24",
+		}, {
+			Id:          fakeIPBlockID("03"),
+			DisplayName: fakeIPBlockName("03"),
+			Cidr:        "invalid",
+		},
+	}
+	ipBlockSubnets := []manager.IpBlockSubnet{
+		{BlockId: fakeIPBlockID("01"), Cidr: "172.16.0.0/24", Size: 256},
+		{BlockId: fakeIPBlockID("01"), Cidr: "172.16.1.0/26", Size: 64},
+	}
+	expectedMetrics := []ipBlockMetric{
+		{
+			ID:      fakeIPBlockID("01"),
+			Name:    fakeIPBlockName("01"),
+			CIDR:    "172.16.0.0/16",
+			Size:    65536,
+			Subnets: 2,
+			Free:    65216,
+		}, {
+			ID:      fakeIPBlockID("02"),
+			Name:    fakeIPBlockName("02"),
+			CIDR:    "172.17.0.0/24",
+			Size:    256,
+			Subnets: 0,
+			Free:    256,
+		},
+	}
+	mockClient := &mockPoolManagementClient{}
+	logger := log.NewNopLogger()
+	collector := newPoolManagementCollector(mockClient, logger)
+	metrics := collector.generateIPBlockMetrics(ipBlocks, ipBlockSubnets)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
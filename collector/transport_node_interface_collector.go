@@ -0,0 +1,223 @@
+package collector
+
+import (
+	"regexp"
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var transportNodeInterfacePossibleLinkStatus = [...]string{"UP", "DOWN"}
+
+var (
+	transportNodeInterfaceInclude = kingpin.Flag("collector.transport_node_interface.include", "Regexp of transport node interfaces to include in interface statistics.").Default(".*").String()
+)
+
+func init() {
+	registerCollector("transport_node_interface", createTransportNodeInterfaceCollectorFactory)
+}
+
+type transportNodeInterfaceCollector struct {
+	transportNodeInterfaceClient client.TransportNodeInterfaceClient
+	logger                       log.Logger
+	interfaceInclude             *regexp.Regexp
+
+	linkStatus *prometheus.Desc
+	rxBytes    *prometheus.Desc
+	rxPackets  *prometheus.Desc
+	rxErrors   *prometheus.Desc
+	rxDropped  *prometheus.Desc
+	txBytes    *prometheus.Desc
+	txPackets  *prometheus.Desc
+	txErrors   *prometheus.Desc
+	txDropped  *prometheus.Desc
+}
+
+type transportNodeInterfaceMetric struct {
+	TransportNodeID   string
+	TransportNodeName string
+	InterfaceID       string
+	InterfaceType     string
+	LinkStatusDetail  map[string]float64
+	RxBytes           float64
+	RxPackets         float64
+	RxErrors          float64
+	RxDropped         float64
+	TxBytes           float64
+	TxPackets         float64
+	TxErrors          float64
+	TxDropped         float64
+}
+
+func createTransportNodeInterfaceCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	interfaceInclude := compileIncludeFlag("collector.transport_node_interface.include", *transportNodeInterfaceInclude, logger)
+	return newTransportNodeInterfaceCollector(nsxtClient, logger, interfaceInclude)
+}
+
+func newTransportNodeInterfaceCollector(transportNodeInterfaceClient client.TransportNodeInterfaceClient, logger log.Logger, interfaceInclude *regexp.Regexp) *transportNodeInterfaceCollector {
+	labels := []string{"transport_node_id", "transport_node_name", "interface", "type"}
+	linkStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "link_status"),
+		"Link status of transport node network interface",
+		append(labels, "status"),
+		nil,
+	)
+	rxBytes := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "rx_bytes"),
+		"Total bytes received (rx) on transport node network interface",
+		labels,
+		nil,
+	)
+	rxPackets := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "rx_packets"),
+		"Total packets received (rx) on transport node network interface",
+		labels,
+		nil,
+	)
+	rxErrors := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "rx_errors"),
+		"Total receive (rx) errors on transport node network interface",
+		labels,
+		nil,
+	)
+	rxDropped := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "rx_dropped_packets"),
+		"Total receive (rx) packets dropped on transport node network interface",
+		labels,
+		nil,
+	)
+	txBytes := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "tx_bytes"),
+		"Total bytes transmitted (tx) on transport node network interface",
+		labels,
+		nil,
+	)
+	txPackets := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "tx_packets"),
+		"Total packets transmitted (tx) on transport node network interface",
+		labels,
+		nil,
+	)
+	txErrors := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "tx_errors"),
+		"Total transmit (tx) errors on transport node network interface",
+		labels,
+		nil,
+	)
+	txDropped := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node_interface", "tx_dropped_packets"),
+		"Total transmit (tx) packets dropped on transport node network interface",
+		labels,
+		nil,
+	)
+	return &transportNodeInterfaceCollector{
+		transportNodeInterfaceClient: transportNodeInterfaceClient,
+		logger:                       logger,
+		interfaceInclude:             interfaceInclude,
+
+		linkStatus: linkStatus,
+		rxBytes:    rxBytes,
+		rxPackets:  rxPackets,
+		rxErrors:   rxErrors,
+		rxDropped:  rxDropped,
+		txBytes:    txBytes,
+		txPackets:  txPackets,
+		txErrors:   txErrors,
+		txDropped:  txDropped,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *transportNodeInterfaceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.linkStatus
+	ch <- c.rxBytes
+	ch <- c.rxPackets
+	ch <- c.rxErrors
+	ch <- c.rxDropped
+	ch <- c.txBytes
+	ch <- c.txPackets
+	ch <- c.txErrors
+	ch <- c.txDropped
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *transportNodeInterfaceCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.interfaceInclude == nil {
+		return
+	}
+	transportNodes, err := c.transportNodeInterfaceClient.ListAllTransportNodes()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list transport nodes", "err", err)
+		return
+	}
+	interfaceMetrics := c.generateTransportNodeInterfaceMetrics(transportNodes)
+	for _, m := range interfaceMetrics {
+		labels := []string{m.TransportNodeID, m.TransportNodeName, m.InterfaceID, m.InterfaceType}
+		for status, value := range m.LinkStatusDetail {
+			ch <- prometheus.MustNewConstMetric(c.linkStatus, prometheus.GaugeValue, value, append(labels, status)...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.rxBytes, prometheus.GaugeValue, m.RxBytes, labels...)
+		ch <- prometheus.MustNewConstMetric(c.rxPackets, prometheus.GaugeValue, m.RxPackets, labels...)
+		ch <- prometheus.MustNewConstMetric(c.rxErrors, prometheus.GaugeValue, m.RxErrors, labels...)
+		ch <- prometheus.MustNewConstMetric(c.rxDropped, prometheus.GaugeValue, m.RxDropped, labels...)
+		ch <- prometheus.MustNewConstMetric(c.txBytes, prometheus.GaugeValue, m.TxBytes, labels...)
+		ch <- prometheus.MustNewConstMetric(c.txPackets, prometheus.GaugeValue, m.TxPackets, labels...)
+		ch <- prometheus.MustNewConstMetric(c.txErrors, prometheus.GaugeValue, m.TxErrors, labels...)
+		ch <- prometheus.MustNewConstMetric(c.txDropped, prometheus.GaugeValue, m.TxDropped, labels...)
+	}
+}
+
+func (c *transportNodeInterfaceCollector) generateTransportNodeInterfaceMetrics(transportNodes []manager.TransportNode) (interfaceMetrics []transportNodeInterfaceMetric) {
+	if c.interfaceInclude == nil {
+		return
+	}
+	for _, transportNode := range transportNodes {
+		interfaces, err := c.transportNodeInterfaceClient.ListTransportNodeInterfaces(transportNode.NodeId)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to list transport node interfaces", "id", transportNode.Id, "err", err)
+			continue
+		}
+		for _, iface := range interfaces {
+			if !c.interfaceInclude.MatchString(iface.InterfaceId) {
+				continue
+			}
+			stats, err := c.transportNodeInterfaceClient.GetTransportNodeInterfaceStatistics(transportNode.NodeId, iface.InterfaceId)
+			if err != nil {
+				level.Error(c.logger).Log("msg", "Unable to get transport node interface statistics", "id", transportNode.Id, "interface", iface.InterfaceId, "err", err)
+				continue
+			}
+			interfaceMetric := transportNodeInterfaceMetric{
+				TransportNodeID:   transportNode.Id,
+				TransportNodeName: transportNode.DisplayName,
+				InterfaceID:       iface.InterfaceId,
+				InterfaceType:     iface.InterfaceType,
+				LinkStatusDetail:  map[string]float64{},
+				RxBytes:           float64(stats.RxBytes),
+				RxPackets:         float64(stats.RxPackets),
+				RxErrors:          float64(stats.RxErrors),
+				RxDropped:         float64(stats.RxDropped),
+				TxBytes:           float64(stats.TxBytes),
+				TxPackets:         float64(stats.TxPackets),
+				TxErrors:          float64(stats.TxErrors),
+				TxDropped:         float64(stats.TxDropped),
+			}
+			for _, possibleStatus := range transportNodeInterfacePossibleLinkStatus {
+				statusValue := 0.0
+				if possibleStatus == strings.ToUpper(iface.LinkStatus) {
+					statusValue = 1.0
+				}
+				interfaceMetric.LinkStatusDetail[possibleStatus] = statusValue
+			}
+			interfaceMetrics = append(interfaceMetrics, interfaceMetric)
+		}
+	}
+	return
+}
@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+const (
+	fakeTransportNodeInterfaceStatisticValue = 512
+)
+
+type transportNodeInterfaceResponse struct {
+	NodeID     string
+	Interfaces []manager.NodeInterfaceProperties
+	Error      error
+}
+
+type transportNodeInterfaceStatisticResponse struct {
+	NodeID      string
+	InterfaceID string
+	Error       error
+}
+
+type transportNodeInterfaceClientMock struct {
+	interfaceResponses          []transportNodeInterfaceResponse
+	interfaceStatisticResponses []transportNodeInterfaceStatisticResponse
+}
+
+func (c *transportNodeInterfaceClientMock) ListAllTransportNodes() ([]manager.TransportNode, error) {
+	panic("unused function. Only used to satisfy TransportNodeInterfaceClient interface")
+}
+
+func (c *transportNodeInterfaceClientMock) ListTransportNodeInterfaces(nodeID string) ([]manager.NodeInterfaceProperties, error) {
+	for _, res := range c.interfaceResponses {
+		if res.NodeID == nodeID {
+			return res.Interfaces, res.Error
+		}
+	}
+	return nil, errors.New("transport node not found")
+}
+
+func (c *transportNodeInterfaceClientMock) GetTransportNodeInterfaceStatistics(nodeID, interfaceID string) (manager.NodeInterfaceStatisticsProperties, error) {
+	for _, res := range c.interfaceStatisticResponses {
+		if res.NodeID == nodeID && res.InterfaceID == interfaceID {
+			return manager.NodeInterfaceStatisticsProperties{
+				InterfaceId: interfaceID,
+				RxBytes:     fakeTransportNodeInterfaceStatisticValue,
+				RxPackets:   fakeTransportNodeInterfaceStatisticValue,
+				RxErrors:    fakeTransportNodeInterfaceStatisticValue,
+				RxDropped:   fakeTransportNodeInterfaceStatisticValue,
+				TxBytes:     fakeTransportNodeInterfaceStatisticValue,
+				TxPackets:   fakeTransportNodeInterfaceStatisticValue,
+				TxErrors:    fakeTransportNodeInterfaceStatisticValue,
+				TxDropped:   fakeTransportNodeInterfaceStatisticValue,
+			}, res.Error
+		}
+	}
+	return manager.NodeInterfaceStatisticsProperties{}, errors.New("interface not found")
+}
+
+func buildExpectedTransportNodeInterfaceMetric(id string, interfaceID string, interfaceType string, linkStatus string) transportNodeInterfaceMetric {
+	linkStatusDetail := map[string]float64{
+		"UP":   0.0,
+		"DOWN": 0.0,
+	}
+	linkStatusDetail[linkStatus] = 1.0
+	return transportNodeInterfaceMetric{
+		TransportNodeID:   fakeTransportNodeID(id),
+		TransportNodeName: fakeTransportNodeName(id),
+		InterfaceID:       interfaceID,
+		InterfaceType:     interfaceType,
+		LinkStatusDetail:  linkStatusDetail,
+		RxBytes:           fakeTransportNodeInterfaceStatisticValue,
+		RxPackets:         fakeTransportNodeInterfaceStatisticValue,
+		RxErrors:          fakeTransportNodeInterfaceStatisticValue,
+		RxDropped:         fakeTransportNodeInterfaceStatisticValue,
+		TxBytes:           fakeTransportNodeInterfaceStatisticValue,
+		TxPackets:         fakeTransportNodeInterfaceStatisticValue,
+		TxErrors:          fakeTransportNodeInterfaceStatisticValue,
+		TxDropped:         fakeTransportNodeInterfaceStatisticValue,
+	}
+}
+
+func TestTransportNodeInterfaceCollector_GenerateTransportNodeInterfaceMetrics(t *testing.T) {
+	transportNodes := []manager.TransportNode{
+		{
+			Id:          fakeTransportNodeID("01"),
+			DisplayName: fakeTransportNodeName("01"),
+			NodeId:      fakeFabricNodeID("01"),
+		}, {
+			Id:          fakeTransportNodeID("02"),
+			DisplayName: fakeTransportNodeName("02"),
+			NodeId:      fakeFabricNodeID("02"),
+		},
+	}
+	testcases := []struct {
+		description                 string
+		interfaceInclude            string
+		interfaceResponses          []transportNodeInterfaceResponse
+		interfaceStatisticResponses []transportNodeInterfaceStatisticResponse
+		expectedMetrics             []transportNodeInterfaceMetric
+	}{
+		{
+			description:      "Should return statistics of all interfaces",
+			interfaceInclude: ".*",
+			interfaceResponses: []transportNodeInterfaceResponse{
+				{
+					NodeID: fakeFabricNodeID("01"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "vmnic0", InterfaceType: "PHYSICAL", LinkStatus: "up"},
+						{InterfaceId: "vmk0", InterfaceType: "VIRTUAL", LinkStatus: "down"},
+					},
+				}, {
+					NodeID: fakeFabricNodeID("02"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "fp-eth0", LinkStatus: "UP"},
+					},
+				},
+			},
+			interfaceStatisticResponses: []transportNodeInterfaceStatisticResponse{
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmnic0"},
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmk0"},
+				{NodeID: fakeFabricNodeID("02"), InterfaceID: "fp-eth0"},
+			},
+			expectedMetrics: []transportNodeInterfaceMetric{
+				buildExpectedTransportNodeInterfaceMetric("01", "vmnic0", "PHYSICAL", "UP"),
+				buildExpectedTransportNodeInterfaceMetric("01", "vmk0", "VIRTUAL", "DOWN"),
+				buildExpectedTransportNodeInterfaceMetric("02", "fp-eth0", "", "UP"),
+			},
+		}, {
+			description:      "Should only return statistics of included interfaces",
+			interfaceInclude: "vmnic.*|fp-eth.*",
+			interfaceResponses: []transportNodeInterfaceResponse{
+				{
+					NodeID: fakeFabricNodeID("01"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "vmnic0", LinkStatus: "UP"},
+						{InterfaceId: "vmk0", LinkStatus: "UP"},
+					},
+				}, {
+					NodeID: fakeFabricNodeID("02"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "fp-eth0", LinkStatus: "UP"},
+					},
+				},
+			},
+			interfaceStatisticResponses: []transportNodeInterfaceStatisticResponse{
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmnic0"},
+				{NodeID: fakeFabricNodeID("02"), InterfaceID: "fp-eth0"},
+			},
+			expectedMetrics: []transportNodeInterfaceMetric{
+				buildExpectedTransportNodeInterfaceMetric("01", "vmnic0", "", "UP"),
+				buildExpectedTransportNodeInterfaceMetric("02", "fp-eth0", "", "UP"),
+			},
+		}, {
+			description:      "Should return empty metrics when interface filter is invalid",
+			interfaceInclude: "vmnic[",
+			interfaceResponses: []transportNodeInterfaceResponse{
+				{
+					NodeID: fakeFabricNodeID("01"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "vmnic0", LinkStatus: "UP"},
+					},
+				},
+			},
+			interfaceStatisticResponses: []transportNodeInterfaceStatisticResponse{
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmnic0"},
+			},
+			expectedMetrics: []transportNodeInterfaceMetric{},
+		}, {
+			description:      "Should only return interfaces with valid response",
+			interfaceInclude: ".*",
+			interfaceResponses: []transportNodeInterfaceResponse{
+				{
+					NodeID: fakeFabricNodeID("01"),
+					Interfaces: []manager.NodeInterfaceProperties{
+						{InterfaceId: "vmnic0", LinkStatus: "UP"},
+						{InterfaceId: "vmnic1", LinkStatus: "UP"},
+					},
+				}, {
+					NodeID: fakeFabricNodeID("02"),
+					Error:  errors.New("error listing interfaces"),
+				},
+			},
+			interfaceStatisticResponses: []transportNodeInterfaceStatisticResponse{
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmnic0"},
+				{NodeID: fakeFabricNodeID("01"), InterfaceID: "vmnic1", Error: errors.New("error getting interface statistics")},
+			},
+			expectedMetrics: []transportNodeInterfaceMetric{
+				buildExpectedTransportNodeInterfaceMetric("01", "vmnic0", "", "UP"),
+			},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &transportNodeInterfaceClientMock{
+			interfaceResponses:          tc.interfaceResponses,
+			interfaceStatisticResponses: tc.interfaceStatisticResponses,
+		}
+		logger := log.NewNopLogger()
+		interfaceInclude := compileIncludeFlag("collector.transport_node_interface.include", tc.interfaceInclude, logger)
+		collector := newTransportNodeInterfaceCollector(mockClient, logger, interfaceInclude)
+		metrics := collector.generateTransportNodeInterfaceMetrics(transportNodes)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
@@ -0,0 +1,265 @@
+package collector
+
+import (
+	"strconv"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func init() {
+	registerCollector("transport_node_system", createTransportNodeSystemCollectorFactory)
+}
+
+type transportNodeSystemCollector struct {
+	transportNodeSystemClient client.TransportNodeSystemClient
+	logger                    log.Logger
+
+	cpuCoresUse          *prometheus.Desc
+	cpuCoresTotal        *prometheus.Desc
+	memoryUse            *prometheus.Desc
+	memoryTotal          *prometheus.Desc
+	memoryCached         *prometheus.Desc
+	swapUse              *prometheus.Desc
+	swapTotal            *prometheus.Desc
+	diskUse              *prometheus.Desc
+	diskTotal            *prometheus.Desc
+	uptime               *prometheus.Desc
+	datapathCPUCoreUsage *prometheus.Desc
+}
+
+type transportNodeSystemMetric struct {
+	ID   string
+	Name string
+	Type string
+
+	CPUCores                  float64
+	LoadAverageOneMinute      float64
+	LoadAverageFiveMinutes    float64
+	LoadAverageFifteenMinutes float64
+	MemoryCached              float64
+	MemoryUse                 float64
+	MemoryTotal               float64
+	SwapUse                   float64
+	SwapTotal                 float64
+	DiskUse                   map[string]float64
+	DiskTotal                 map[string]float64
+	UptimeSeconds             float64
+	DatapathCPUCoreUsage      map[string]float64
+}
+
+func createTransportNodeSystemCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newTransportNodeSystemCollector(nsxtClient, logger)
+}
+
+func newTransportNodeSystemCollector(transportNodeSystemClient client.TransportNodeSystemClient, logger log.Logger) *transportNodeSystemCollector {
+	cpuCoresUse := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "cpu_use_cores"),
+		"NSX-T transport node average load",
+		[]string{"id", "name", "type", "minutes"},
+		nil,
+	)
+	cpuCoresTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "cpu_total_cores"),
+		"NSX-T transport node cpu cores total",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	memoryUse := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "memory_use_kilobytes"),
+		"NSX-T transport node memory use",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	memoryTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "memory_total_kilobytes"),
+		"NSX-T transport node memory total",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	memoryCached := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "memory_cached_kilobytes"),
+		"NSX-T transport node cached memory",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	swapUse := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "swap_use_kilobytes"),
+		"NSX-T transport node swap use",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	swapTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "swap_total_kilobytes"),
+		"NSX-T transport node swap total",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	diskUse := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "disk_use_kilobytes"),
+		"NSX-T transport node disk use",
+		[]string{"id", "name", "type", "filesystem"},
+		nil,
+	)
+	diskTotal := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "disk_total_kilobytes"),
+		"NSX-T transport node disk total",
+		[]string{"id", "name", "type", "filesystem"},
+		nil,
+	)
+	uptime := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "uptime_seconds"),
+		"NSX-T transport node time since system start",
+		[]string{"id", "name", "type"},
+		nil,
+	)
+	datapathCPUCoreUsage := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_node", "datapath_cpu_core_usage_percent"),
+		"NSX-T edge transport node datapath cpu usage per core",
+		[]string{"id", "name", "type", "core"},
+		nil,
+	)
+	return &transportNodeSystemCollector{
+		transportNodeSystemClient: transportNodeSystemClient,
+		logger:                    logger,
+
+		cpuCoresUse:          cpuCoresUse,
+		cpuCoresTotal:        cpuCoresTotal,
+		memoryUse:            memoryUse,
+		memoryTotal:          memoryTotal,
+		memoryCached:         memoryCached,
+		swapUse:              swapUse,
+		swapTotal:            swapTotal,
+		diskUse:              diskUse,
+		diskTotal:            diskTotal,
+		uptime:               uptime,
+		datapathCPUCoreUsage: datapathCPUCoreUsage,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *transportNodeSystemCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.cpuCoresUse
+	ch <- c.cpuCoresTotal
+	ch <- c.memoryUse
+	ch <- c.memoryTotal
+	ch <- c.memoryCached
+	ch <- c.swapUse
+	ch <- c.swapTotal
+	ch <- c.diskUse
+	ch <- c.diskTotal
+	ch <- c.uptime
+	ch <- c.datapathCPUCoreUsage
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *transportNodeSystemCollector) Collect(ch chan<- prometheus.Metric) {
+	transportNodes, err := c.transportNodeSystemClient.ListAllTransportNodes()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list transport nodes", "err", err)
+		return
+	}
+	edgeClusters, err := c.transportNodeSystemClient.ListAllEdgeClusters()
+	if err != nil {
+		edgeClusters = nil
+		level.Error(c.logger).Log("msg", "Unable to list edge clusters", "err", err)
+	}
+	transportNodeSystemMetrics := c.generateTransportNodeSystemMetrics(transportNodes, edgeClusters)
+	for _, m := range transportNodeSystemMetrics {
+		labels := []string{m.ID, m.Name, m.Type}
+		ch <- prometheus.MustNewConstMetric(c.cpuCoresUse, prometheus.GaugeValue, m.LoadAverageOneMinute, append(labels, "1")...)
+		ch <- prometheus.MustNewConstMetric(c.cpuCoresUse, prometheus.GaugeValue, m.LoadAverageFiveMinutes, append(labels, "5")...)
+		ch <- prometheus.MustNewConstMetric(c.cpuCoresUse, prometheus.GaugeValue, m.LoadAverageFifteenMinutes, append(labels, "15")...)
+		ch <- prometheus.MustNewConstMetric(c.cpuCoresTotal, prometheus.GaugeValue, m.CPUCores, labels...)
+		ch <- prometheus.MustNewConstMetric(c.memoryUse, prometheus.GaugeValue, m.MemoryUse, labels...)
+		ch <- prometheus.MustNewConstMetric(c.memoryTotal, prometheus.GaugeValue, m.MemoryTotal, labels...)
+		ch <- prometheus.MustNewConstMetric(c.memoryCached, prometheus.GaugeValue, m.MemoryCached, labels...)
+		ch <- prometheus.MustNewConstMetric(c.swapUse, prometheus.GaugeValue, m.SwapUse, labels...)
+		ch <- prometheus.MustNewConstMetric(c.swapTotal, prometheus.GaugeValue, m.SwapTotal, labels...)
+		ch <- prometheus.MustNewConstMetric(c.uptime, prometheus.GaugeValue, m.UptimeSeconds, labels...)
+		for filesystem, diskUse := range m.DiskUse {
+			ch <- prometheus.MustNewConstMetric(c.diskUse, prometheus.GaugeValue, diskUse, append(labels, filesystem)...)
+		}
+		for filesystem, diskTotal := range m.DiskTotal {
+			ch <- prometheus.MustNewConstMetric(c.diskTotal, prometheus.GaugeValue, diskTotal, append(labels, filesystem)...)
+		}
+		for core, usage := range m.DatapathCPUCoreUsage {
+			ch <- prometheus.MustNewConstMetric(c.datapathCPUCoreUsage, prometheus.GaugeValue, usage, append(labels, core)...)
+		}
+	}
+}
+
+func (c *transportNodeSystemCollector) generateTransportNodeSystemMetrics(transportNodes []manager.TransportNode, edgeClusters []manager.EdgeCluster) (transportNodeSystemMetrics []transportNodeSystemMetric) {
+	edgeTransportNodeIDs := make(map[string]bool)
+	for _, ec := range edgeClusters {
+		for _, member := range ec.Members {
+			edgeTransportNodeIDs[member.TransportNodeId] = true
+		}
+	}
+	for _, transportNode := range transportNodes {
+		nodeStatus, err := c.transportNodeSystemClient.GetTransportNodeSystemStatus(transportNode.NodeId)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get transport node system status", "id", transportNode.Id, "err", err)
+			continue
+		}
+		if nodeStatus.SystemStatus == nil {
+			level.Warn(c.logger).Log("msg", "Transport node system status is not available", "id", transportNode.Id)
+			continue
+		}
+		var transportNodeType string
+		if edgeClusters != nil {
+			transportNodeType = "host"
+		}
+		if edgeTransportNodeIDs[transportNode.Id] {
+			transportNodeType = "edge"
+		}
+		prop := nodeStatus.SystemStatus
+		transportNodeSystemMetric := transportNodeSystemMetric{
+			ID:            transportNode.Id,
+			Name:          transportNode.DisplayName,
+			Type:          transportNodeType,
+			CPUCores:      float64(prop.CpuCores),
+			MemoryUse:     float64(prop.MemUsed),
+			MemoryTotal:   float64(prop.MemTotal),
+			MemoryCached:  float64(prop.MemCache),
+			SwapUse:       float64(prop.SwapUsed),
+			SwapTotal:     float64(prop.SwapTotal),
+			UptimeSeconds: float64(prop.Uptime) / 1000,
+			DiskUse:       make(map[string]float64),
+			DiskTotal:     make(map[string]float64),
+		}
+		if len(prop.LoadAverage) == 3 {
+			transportNodeSystemMetric.LoadAverageOneMinute = float64(prop.LoadAverage[0])
+			transportNodeSystemMetric.LoadAverageFiveMinutes = float64(prop.LoadAverage[1])
+			transportNodeSystemMetric.LoadAverageFifteenMinutes = float64(prop.LoadAverage[2])
+		}
+		for _, disk := range prop.FileSystems {
+			transportNodeSystemMetric.DiskUse[disk.Mount] = float64(disk.Used)
+			transportNodeSystemMetric.DiskTotal[disk.Mount] = float64(disk.Total)
+		}
+		if transportNodeType == "edge" {
+			transportNodeSystemMetric.DatapathCPUCoreUsage = c.generateDatapathCPUCoreUsage(transportNode.Id)
+		}
+		transportNodeSystemMetrics = append(transportNodeSystemMetrics, transportNodeSystemMetric)
+	}
+	return
+}
+
+func (c *transportNodeSystemCollector) generateDatapathCPUCoreUsage(transportNodeID string) map[string]float64 {
+	cpuStats, err := c.transportNodeSystemClient.GetEdgeDatapathCPUStats(transportNodeID)
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get edge datapath cpu stats", "id", transportNodeID, "err", err)
+		return nil
+	}
+	coreUsage := make(map[string]float64)
+	for _, core := range cpuStats.Cores {
+		coreUsage[strconv.FormatInt(core.Core, 10)] = core.Usage
+	}
+	return coreUsage
+}
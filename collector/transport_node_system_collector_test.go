@@ -0,0 +1,208 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+const (
+	fakeTransportNodeSystemValue = 1024
+)
+
+func fakeFabricNodeID(id string) string {
+	return "fake-fabric-node-id-" + id
+}
+
+type transportNodeSystemStatusResponse struct {
+	NodeID       string
+	SystemStatus *manager.NodeStatusProperties
+	Error        error
+}
+
+type datapathCPUStatsResponse struct {
+	TransportNodeID string
+	CPUStats        client.DatapathCPUStats
+	Error           error
+}
+
+type transportNodeSystemClientMock struct {
+	systemStatusResponses     []transportNodeSystemStatusResponse
+	datapathCPUStatsResponses []datapathCPUStatsResponse
+}
+
+func (c *transportNodeSystemClientMock) ListAllTransportNodes() ([]manager.TransportNode, error) {
+	panic("unused function. Only used to satisfy TransportNodeSystemClient interface")
+}
+
+func (c *transportNodeSystemClientMock) ListAllEdgeClusters() ([]manager.EdgeCluster, error) {
+	panic("unused function. Only used to satisfy TransportNodeSystemClient interface")
+}
+
+func (c *transportNodeSystemClientMock) GetTransportNodeSystemStatus(nodeID string) (manager.NodeStatus, error) {
+	for _, res := range c.systemStatusResponses {
+		if res.NodeID == nodeID {
+			return manager.NodeStatus{
+				SystemStatus: res.SystemStatus,
+			}, res.Error
+		}
+	}
+	return manager.NodeStatus{}, errors.New("node status not found")
+}
+
+func (c *transportNodeSystemClientMock) GetEdgeDatapathCPUStats(transportNodeID string) (client.DatapathCPUStats, error) {
+	for _, res := range c.datapathCPUStatsResponses {
+		if res.TransportNodeID == transportNodeID {
+			return res.CPUStats, res.Error
+		}
+	}
+	return client.DatapathCPUStats{}, errors.New("datapath cpu stats not found")
+}
+
+func buildTransportNodeSystemStatusProperties() *manager.NodeStatusProperties {
+	return &manager.NodeStatusProperties{
+		CpuCores:    4,
+		LoadAverage: []float32{1, 5, 15},
+		MemUsed:     fakeTransportNodeSystemValue,
+		MemTotal:    fakeTransportNodeSystemValue,
+		MemCache:    fakeTransportNodeSystemValue,
+		SwapUsed:    fakeTransportNodeSystemValue,
+		SwapTotal:   fakeTransportNodeSystemValue,
+		Uptime:      60000,
+		FileSystems: []manager.NodeFileSystemProperties{
+			{
+				Mount: "/",
+				Used:  fakeTransportNodeSystemValue,
+				Total: fakeTransportNodeSystemValue,
+			},
+		},
+	}
+}
+
+func buildExpectedTransportNodeSystemMetric(id string, nodeType string, datapathCPUCoreUsage map[string]float64) transportNodeSystemMetric {
+	return transportNodeSystemMetric{
+		ID:                        fakeTransportNodeID(id),
+		Name:                      fakeTransportNodeName(id),
+		Type:                      nodeType,
+		CPUCores:                  4,
+		LoadAverageOneMinute:      1,
+		LoadAverageFiveMinutes:    5,
+		LoadAverageFifteenMinutes: 15,
+		MemoryUse:                 fakeTransportNodeSystemValue,
+		MemoryTotal:               fakeTransportNodeSystemValue,
+		MemoryCached:              fakeTransportNodeSystemValue,
+		SwapUse:                   fakeTransportNodeSystemValue,
+		SwapTotal:                 fakeTransportNodeSystemValue,
+		UptimeSeconds:             60,
+		DiskUse:                   map[string]float64{"/": fakeTransportNodeSystemValue},
+		DiskTotal:                 map[string]float64{"/": fakeTransportNodeSystemValue},
+		DatapathCPUCoreUsage:      datapathCPUCoreUsage,
+	}
+}
+
+func TestTransportNodeSystemCollector_GenerateTransportNodeSystemMetrics(t *testing.T) {
+	transportNodes := []manager.TransportNode{
+		{
+			Id:          fakeTransportNodeID("01"),
+			DisplayName: fakeTransportNodeName("01"),
+			NodeId:      fakeFabricNodeID("01"),
+		}, {
+			Id:          fakeTransportNodeID("02"),
+			DisplayName: fakeTransportNodeName("02"),
+			NodeId:      fakeFabricNodeID("02"),
+		},
+	}
+	edgeClusters := []manager.EdgeCluster{
+		{
+			Id: fakeEdgeClusterID("01"),
+			Members: []manager.EdgeClusterMember{
+				{
+					TransportNodeId: fakeTransportNodeID("01"),
+				},
+			},
+		},
+	}
+	testcases := []struct {
+		description               string
+		edgeClusters              []manager.EdgeCluster
+		systemStatusResponses     []transportNodeSystemStatusResponse
+		datapathCPUStatsResponses []datapathCPUStatsResponse
+		expectedMetrics           []transportNodeSystemMetric
+	}{
+		{
+			description:  "Should return system metrics with datapath cpu usage for edge nodes only",
+			edgeClusters: edgeClusters,
+			systemStatusResponses: []transportNodeSystemStatusResponse{
+				{
+					NodeID:       fakeFabricNodeID("01"),
+					SystemStatus: buildTransportNodeSystemStatusProperties(),
+				}, {
+					NodeID:       fakeFabricNodeID("02"),
+					SystemStatus: buildTransportNodeSystemStatusProperties(),
+				},
+			},
+			datapathCPUStatsResponses: []datapathCPUStatsResponse{
+				{
+					TransportNodeID: fakeTransportNodeID("01"),
+					CPUStats: client.DatapathCPUStats{
+						Cores: []client.DatapathCPUCoreStats{
+							{Core: 0, Usage: 12.5},
+							{Core: 1, Usage: 80},
+						},
+					},
+				},
+			},
+			expectedMetrics: []transportNodeSystemMetric{
+				buildExpectedTransportNodeSystemMetric("01", "edge", map[string]float64{"0": 12.5, "1": 80}),
+				buildExpectedTransportNodeSystemMetric("02", "host", nil),
+			},
+		}, {
+			description:  "Should keep edge system metrics when datapath cpu stats are unavailable",
+			edgeClusters: edgeClusters,
+			systemStatusResponses: []transportNodeSystemStatusResponse{
+				{
+					NodeID:       fakeFabricNodeID("01"),
+					SystemStatus: buildTransportNodeSystemStatusProperties(),
+				},
+			},
+			datapathCPUStatsResponses: []datapathCPUStatsResponse{
+				{
+					TransportNodeID: fakeTransportNodeID("01"),
+					Error:           errors.New("error getting datapath cpu stats"),
+				},
+			},
+			expectedMetrics: []transportNodeSystemMetric{
+				buildExpectedTransportNodeSystemMetric("01", "edge", nil),
+			},
+		}, {
+			description:  "Should skip transport nodes without system status",
+			edgeClusters: nil,
+			systemStatusResponses: []transportNodeSystemStatusResponse{
+				{
+					NodeID:       fakeFabricNodeID("01"),
+					SystemStatus: nil,
+				}, {
+					NodeID:       fakeFabricNodeID("02"),
+					SystemStatus: buildTransportNodeSystemStatusProperties(),
+					Error:        errors.New("error getting node status"),
+				},
+			},
+			expectedMetrics: []transportNodeSystemMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &transportNodeSystemClientMock{
+			systemStatusResponses:     tc.systemStatusResponses,
+			datapathCPUStatsResponses: tc.datapathCPUStatsResponses,
+		}
+		logger := log.NewNopLogger()
+		collector := newTransportNodeSystemCollector(mockClient, logger)
+		metrics := collector.generateTransportNodeSystemMetrics(transportNodes, tc.edgeClusters)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func init() {
+	registerCollector("transport_zone", createTransportZoneCollectorFactory)
+}
+
+type transportZoneCollector struct {
+	transportZoneClient client.TransportZoneClient
+	logger              log.Logger
+
+	transportZoneInfo            *prometheus.Desc
+	transportZoneTransportNodes  *prometheus.Desc
+	transportZoneLogicalSwitches *prometheus.Desc
+	transportZoneLogicalPorts    *prometheus.Desc
+}
+
+type transportZoneMetric struct {
+	ID                 string
+	Name               string
+	Type               string
+	HostSwitchName     string
+	NumTransportNodes  float64
+	NumLogicalSwitches float64
+	NumLogicalPorts    float64
+}
+
+func createTransportZoneCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newTransportZoneCollector(nsxtClient, logger)
+}
+
+func newTransportZoneCollector(transportZoneClient client.TransportZoneClient, logger log.Logger) *transportZoneCollector {
+	transportZoneInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_zone", "info"),
+		"Info of transport zone",
+		[]string{"id", "name", "type", "host_switch_name"},
+		nil,
+	)
+	transportZoneTransportNodes := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_zone", "transport_nodes"),
+		"Number of transport nodes in transport zone",
+		[]string{"id", "name"},
+		nil,
+	)
+	transportZoneLogicalSwitches := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_zone", "logical_switches"),
+		"Number of logical switches in transport zone",
+		[]string{"id", "name"},
+		nil,
+	)
+	transportZoneLogicalPorts := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "transport_zone", "logical_ports"),
+		"Number of logical ports in transport zone",
+		[]string{"id", "name"},
+		nil,
+	)
+	return &transportZoneCollector{
+		transportZoneClient:          transportZoneClient,
+		logger:                       logger,
+		transportZoneInfo:            transportZoneInfo,
+		transportZoneTransportNodes:  transportZoneTransportNodes,
+		transportZoneLogicalSwitches: transportZoneLogicalSwitches,
+		transportZoneLogicalPorts:    transportZoneLogicalPorts,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *transportZoneCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.transportZoneInfo
+	ch <- c.transportZoneTransportNodes
+	ch <- c.transportZoneLogicalSwitches
+	ch <- c.transportZoneLogicalPorts
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *transportZoneCollector) Collect(ch chan<- prometheus.Metric) {
+	transportZones, err := c.transportZoneClient.ListAllTransportZones()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to list transport zones", "err", err)
+		return
+	}
+	transportZoneMetrics := c.generateTransportZoneMetrics(transportZones)
+	for _, m := range transportZoneMetrics {
+		ch <- prometheus.MustNewConstMetric(c.transportZoneInfo, prometheus.GaugeValue, 1.0, m.ID, m.Name, m.Type, m.HostSwitchName)
+		labels := []string{m.ID, m.Name}
+		ch <- prometheus.MustNewConstMetric(c.transportZoneTransportNodes, prometheus.GaugeValue, m.NumTransportNodes, labels...)
+		ch <- prometheus.MustNewConstMetric(c.transportZoneLogicalSwitches, prometheus.GaugeValue, m.NumLogicalSwitches, labels...)
+		ch <- prometheus.MustNewConstMetric(c.transportZoneLogicalPorts, prometheus.GaugeValue, m.NumLogicalPorts, labels...)
+	}
+}
+
+func (c *transportZoneCollector) generateTransportZoneMetrics(transportZones []manager.TransportZone) (transportZoneMetrics []transportZoneMetric) {
+	for _, transportZone := range transportZones {
+		transportZoneStatus, err := c.transportZoneClient.GetTransportZoneStatus(transportZone.Id)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to get transport zone status", "id", transportZone.Id, "err", err)
+			continue
+		}
+		transportZoneMetric := transportZoneMetric{
+			ID:                 transportZone.Id,
+			Name:               transportZone.DisplayName,
+			Type:               transportZone.TransportType,
+			HostSwitchName:     transportZone.HostSwitchName,
+			NumTransportNodes:  float64(transportZoneStatus.NumTransportNodes),
+			NumLogicalSwitches: float64(transportZoneStatus.NumLogicalSwitches),
+			NumLogicalPorts:    float64(transportZoneStatus.NumLogicalPorts),
+		}
+		transportZoneMetrics = append(transportZoneMetrics, transportZoneMetric)
+	}
+	return
+}
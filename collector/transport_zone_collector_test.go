@@ -0,0 +1,135 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+const (
+	fakeTransportZoneName           = "fake-transport-zone-name"
+	fakeTransportZoneHostSwitchName = "fake-host-switch"
+)
+
+type mockTransportZoneClient struct {
+	responses []mockTransportZoneResponse
+}
+
+type mockTransportZoneResponse struct {
+	ID     string
+	Status manager.TransportZoneStatus
+	Error  error
+}
+
+func (c *mockTransportZoneClient) ListAllTransportZones() ([]manager.TransportZone, error) {
+	panic("unused function. Only used to satisfy TransportZoneClient interface")
+}
+
+func (c *mockTransportZoneClient) GetTransportZoneStatus(zoneID string) (manager.TransportZoneStatus, error) {
+	for _, res := range c.responses {
+		if res.ID == zoneID {
+			return res.Status, res.Error
+		}
+	}
+	return manager.TransportZoneStatus{}, errors.New("transport zone not found")
+}
+
+func buildTransportZone(id string, transportType string) manager.TransportZone {
+	return manager.TransportZone{
+		Id:             fakeTransportZoneID(id),
+		DisplayName:    fakeTransportZoneName + "-" + id,
+		TransportType:  transportType,
+		HostSwitchName: fakeTransportZoneHostSwitchName,
+	}
+}
+
+func buildTransportZoneStatusResponse(id string, count int32, err error) mockTransportZoneResponse {
+	return mockTransportZoneResponse{
+		ID: fakeTransportZoneID(id),
+		Status: manager.TransportZoneStatus{
+			TransportZoneId:    fakeTransportZoneID(id),
+			NumTransportNodes:  count,
+			NumLogicalSwitches: count,
+			NumLogicalPorts:    count,
+		},
+		Error: err,
+	}
+}
+
+func TestTransportZoneCollector_GenerateTransportZoneMetrics(t *testing.T) {
+	testcases := []struct {
+		description     string
+		transportZones  []manager.TransportZone
+		responses       []mockTransportZoneResponse
+		expectedMetrics []transportZoneMetric
+	}{
+		{
+			description: "Should return correct transport zone metrics",
+			transportZones: []manager.TransportZone{
+				buildTransportZone("01", "OVERLAY"),
+				buildTransportZone("02", "VLAN"),
+			},
+			responses: []mockTransportZoneResponse{
+				buildTransportZoneStatusResponse("01", 3, nil),
+				buildTransportZoneStatusResponse("02", 5, nil),
+			},
+			expectedMetrics: []transportZoneMetric{
+				{
+					ID:                 fakeTransportZoneID("01"),
+					Name:               fakeTransportZoneName + "-01",
+					Type:               "OVERLAY",
+					HostSwitchName:     fakeTransportZoneHostSwitchName,
+					NumTransportNodes:  3,
+					NumLogicalSwitches: 3,
+					NumLogicalPorts:    3,
+				}, {
+					ID:                 fakeTransportZoneID("02"),
+					Name:               fakeTransportZoneName + "-02",
+					Type:               "VLAN",
+					HostSwitchName:     fakeTransportZoneHostSwitchName,
+					NumTransportNodes:  5,
+					NumLogicalSwitches: 5,
+					NumLogicalPorts:    5,
+				},
+			},
+		}, {
+			description: "Should only return transport zone with valid status response",
+			transportZones: []manager.TransportZone{
+				buildTransportZone("01", "OVERLAY"),
+				buildTransportZone("02", "VLAN"),
+			},
+			responses: []mockTransportZoneResponse{
+				buildTransportZoneStatusResponse("01", 3, nil),
+				buildTransportZoneStatusResponse("02", 5, errors.New("error getting transport zone status")),
+			},
+			expectedMetrics: []transportZoneMetric{
+				{
+					ID:                 fakeTransportZoneID("01"),
+					Name:               fakeTransportZoneName + "-01",
+					Type:               "OVERLAY",
+					HostSwitchName:     fakeTransportZoneHostSwitchName,
+					NumTransportNodes:  3,
+					NumLogicalSwitches: 3,
+					NumLogicalPorts:    3,
+				},
+			},
+		}, {
+			description:     "Should return empty metrics when given empty transport zones",
+			transportZones:  []manager.TransportZone{},
+			responses:       []mockTransportZoneResponse{},
+			expectedMetrics: []transportZoneMetric{},
+		},
+	}
+	for _, tc := range testcases {
+		mockClient := &mockTransportZoneClient{
+			responses: tc.responses,
+		}
+		logger := log.NewNopLogger()
+		collector := newTransportZoneCollector(mockClient, logger)
+		metrics := collector.generateTransportZoneMetrics(tc.transportZones)
+		assert.ElementsMatch(t, tc.expectedMetrics, metrics, tc.description)
+	}
+}
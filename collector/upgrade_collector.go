@@ -0,0 +1,221 @@
+package collector
+
+import (
+	"errors"
+	"strings"
+
+	"nsxt_exporter/client"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	nsxt "github.com/vmware/go-vmware-nsxt"
+	"github.com/vmware/go-vmware-nsxt/upgrade"
+)
+
+var upgradePossibleStatus = [...]string{"NOT_STARTED", "IN_PROGRESS", "PAUSING", "PAUSED", "SUCCESS", "FAILED"}
+
+func init() {
+	registerCollector("upgrade", createUpgradeCollectorFactory)
+}
+
+type upgradeCollector struct {
+	upgradeClient client.UpgradeClient
+	logger        log.Logger
+
+	upgradeInfo                     *prometheus.Desc
+	upgradeStatus                   *prometheus.Desc
+	upgradeComponentInfo            *prometheus.Desc
+	upgradeComponentStatus          *prometheus.Desc
+	upgradeComponentPercentComplete *prometheus.Desc
+	upgradeComponentFailedUnits     *prometheus.Desc
+	upgradeUnits                    *prometheus.Desc
+	upgradeUnitsWithIssues          *prometheus.Desc
+}
+
+type upgradeComponentMetric struct {
+	ComponentType   string
+	TargetVersion   string
+	StatusDetail    map[string]float64
+	PercentComplete float64
+	FailedUnits     float64
+}
+
+type upgradeUnitMetric struct {
+	ComponentType   string
+	Version         string
+	Units           float64
+	UnitsWithIssues float64
+}
+
+func createUpgradeCollectorFactory(apiClient *nsxt.APIClient, logger log.Logger) prometheus.Collector {
+	nsxtClient := client.NewNSXTClient(apiClient, logger)
+	return newUpgradeCollector(nsxtClient, logger)
+}
+
+func newUpgradeCollector(upgradeClient client.UpgradeClient, logger log.Logger) *upgradeCollector {
+	upgradeInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "info"),
+		"Info of current and target system version",
+		[]string{"system_version", "target_version", "upgrade_coordinator_version"},
+		nil,
+	)
+	upgradeStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "status"),
+		"Overall upgrade status",
+		[]string{"status"},
+		nil,
+	)
+	upgradeComponentInfo := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "component_info"),
+		"Info of upgrade component target version",
+		[]string{"component_type", "target_version"},
+		nil,
+	)
+	upgradeComponentStatus := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "component_status"),
+		"Upgrade status of component",
+		[]string{"component_type", "status"},
+		nil,
+	)
+	upgradeComponentPercentComplete := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "component_percent_complete"),
+		"Upgrade progress of component in percentage",
+		[]string{"component_type"},
+		nil,
+	)
+	upgradeComponentFailedUnits := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "component_failed_units"),
+		"Number of upgrade units of component failed to upgrade",
+		[]string{"component_type"},
+		nil,
+	)
+	upgradeUnits := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "units"),
+		"Number of upgrade units of component per current version",
+		[]string{"component_type", "version"},
+		nil,
+	)
+	upgradeUnitsWithIssues := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upgrade", "units_with_issues"),
+		"Number of upgrade units of component with issues per current version",
+		[]string{"component_type", "version"},
+		nil,
+	)
+	return &upgradeCollector{
+		upgradeClient: upgradeClient,
+		logger:        logger,
+
+		upgradeInfo:                     upgradeInfo,
+		upgradeStatus:                   upgradeStatus,
+		upgradeComponentInfo:            upgradeComponentInfo,
+		upgradeComponentStatus:          upgradeComponentStatus,
+		upgradeComponentPercentComplete: upgradeComponentPercentComplete,
+		upgradeComponentFailedUnits:     upgradeComponentFailedUnits,
+		upgradeUnits:                    upgradeUnits,
+		upgradeUnitsWithIssues:          upgradeUnitsWithIssues,
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *upgradeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.upgradeInfo
+	ch <- c.upgradeStatus
+	ch <- c.upgradeComponentInfo
+	ch <- c.upgradeComponentStatus
+	ch <- c.upgradeComponentPercentComplete
+	ch <- c.upgradeComponentFailedUnits
+	ch <- c.upgradeUnits
+	ch <- c.upgradeUnitsWithIssues
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *upgradeCollector) Collect(ch chan<- prometheus.Metric) {
+	upgradeSummary, err := c.upgradeClient.GetUpgradeSummary()
+	if errors.Is(err, client.ErrUpgradeCoordinatorNotRunning) {
+		// Upgrade coordinator is usually not running outside of an upgrade window.
+		level.Debug(c.logger).Log("msg", "Unable to get upgrade summary", "err", err)
+		return
+	}
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get upgrade summary", "err", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.upgradeInfo, prometheus.GaugeValue, 1.0, upgradeSummary.SystemVersion, upgradeSummary.TargetVersion, upgradeSummary.UpgradeCoordinatorVersion)
+	for status, value := range c.constructStatusDetail(upgradePossibleStatus[:], upgradeSummary.UpgradeStatus) {
+		ch <- prometheus.MustNewConstMetric(c.upgradeStatus, prometheus.GaugeValue, value, status)
+	}
+
+	upgradeStatus, err := c.upgradeClient.GetUpgradeStatusSummary()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get upgrade status summary", "err", err)
+	} else {
+		for _, m := range c.generateUpgradeComponentMetrics(upgradeStatus, upgradeSummary.ComponentTargetVersions) {
+			ch <- prometheus.MustNewConstMetric(c.upgradeComponentInfo, prometheus.GaugeValue, 1.0, m.ComponentType, m.TargetVersion)
+			for status, value := range m.StatusDetail {
+				ch <- prometheus.MustNewConstMetric(c.upgradeComponentStatus, prometheus.GaugeValue, value, m.ComponentType, status)
+			}
+			ch <- prometheus.MustNewConstMetric(c.upgradeComponentPercentComplete, prometheus.GaugeValue, m.PercentComplete, m.ComponentType)
+			ch <- prometheus.MustNewConstMetric(c.upgradeComponentFailedUnits, prometheus.GaugeValue, m.FailedUnits, m.ComponentType)
+		}
+	}
+
+	upgradeUnitsStats, err := c.upgradeClient.GetUpgradeUnitsStats()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "Unable to get upgrade units stats", "err", err)
+		return
+	}
+	for _, m := range c.generateUpgradeUnitMetrics(upgradeUnitsStats) {
+		ch <- prometheus.MustNewConstMetric(c.upgradeUnits, prometheus.GaugeValue, m.Units, m.ComponentType, m.Version)
+		ch <- prometheus.MustNewConstMetric(c.upgradeUnitsWithIssues, prometheus.GaugeValue, m.UnitsWithIssues, m.ComponentType, m.Version)
+	}
+}
+
+func (c *upgradeCollector) generateUpgradeComponentMetrics(upgradeStatus upgrade.UpgradeStatus, componentTargetVersions []upgrade.ComponentTargetVersion) (upgradeComponentMetrics []upgradeComponentMetric) {
+	targetVersions := make(map[string]string)
+	for _, componentTargetVersion := range componentTargetVersions {
+		targetVersions[componentTargetVersion.ComponentType] = componentTargetVersion.TargetVersion
+	}
+	for _, componentStatus := range upgradeStatus.ComponentStatus {
+		upgradeComponentMetric := upgradeComponentMetric{
+			ComponentType:   componentStatus.ComponentType,
+			TargetVersion:   targetVersions[componentStatus.ComponentType],
+			StatusDetail:    c.constructStatusDetail(upgradePossibleStatus[:], componentStatus.Status),
+			PercentComplete: float64(componentStatus.PercentComplete),
+		}
+		upgradeUnitGroupStatuses, err := c.upgradeClient.ListAllUpgradeUnitGroupStatuses(componentStatus.ComponentType)
+		if err != nil {
+			level.Error(c.logger).Log("msg", "Unable to list upgrade unit group status", "component_type", componentStatus.ComponentType, "err", err)
+		}
+		for _, upgradeUnitGroupStatus := range upgradeUnitGroupStatuses {
+			upgradeComponentMetric.FailedUnits += float64(upgradeUnitGroupStatus.FailedCount)
+		}
+		upgradeComponentMetrics = append(upgradeComponentMetrics, upgradeComponentMetric)
+	}
+	return
+}
+
+func (c *upgradeCollector) constructStatusDetail(possibleStatus []string, currentStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range possibleStatus {
+		statusValue := 0.0
+		if status == strings.ToUpper(currentStatus) {
+			statusValue = 1.0
+		}
+		statusDetail[status] = statusValue
+	}
+	return statusDetail
+}
+
+func (c *upgradeCollector) generateUpgradeUnitMetrics(upgradeUnitsStats []upgrade.UpgradeUnitTypeStats) (upgradeUnitMetrics []upgradeUnitMetric) {
+	for _, upgradeUnitTypeStats := range upgradeUnitsStats {
+		upgradeUnitMetric := upgradeUnitMetric{
+			ComponentType:   upgradeUnitTypeStats.Type_,
+			Version:         upgradeUnitTypeStats.Version,
+			Units:           float64(upgradeUnitTypeStats.NodeCount),
+			UnitsWithIssues: float64(upgradeUnitTypeStats.NodeWithIssuesCount),
+		}
+		upgradeUnitMetrics = append(upgradeUnitMetrics, upgradeUnitMetric)
+	}
+	return
+}
@@ -0,0 +1,158 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/go-vmware-nsxt/upgrade"
+)
+
+const (
+	fakeUpgradeCurrentVersion = "2.5.1.0.0.15314288"
+	fakeUpgradeTargetVersion  = "3.0.1.0.0.16404613"
+)
+
+type upgradeUnitGroupStatusesResponse struct {
+	ComponentType            string
+	UpgradeUnitGroupStatuses []upgrade.UpgradeUnitGroupStatus
+	Error                    error
+}
+
+type mockUpgradeClient struct {
+	upgradeUnitGroupStatusesResponses []upgradeUnitGroupStatusesResponse
+}
+
+func (c *mockUpgradeClient) GetUpgradeSummary() (upgrade.UpgradeSummary, error) {
+	panic("unused function. Only used to satisfy UpgradeClient interface")
+}
+
+func (c *mockUpgradeClient) GetUpgradeStatusSummary() (upgrade.UpgradeStatus, error) {
+	panic("unused function. Only used to satisfy UpgradeClient interface")
+}
+
+func (c *mockUpgradeClient) ListAllUpgradeUnitGroupStatuses(componentType string) ([]upgrade.UpgradeUnitGroupStatus, error) {
+	for _, res := range c.upgradeUnitGroupStatusesResponses {
+		if res.ComponentType == componentType {
+			return res.UpgradeUnitGroupStatuses, res.Error
+		}
+	}
+	return nil, errors.New("upgrade unit group status not found")
+}
+
+func (c *mockUpgradeClient) GetUpgradeUnitsStats() ([]upgrade.UpgradeUnitTypeStats, error) {
+	panic("unused function. Only used to satisfy UpgradeClient interface")
+}
+
+func buildExpectedUpgradeStatusDetail(nonZeroStatus string) map[string]float64 {
+	statusDetail := map[string]float64{}
+	for _, status := range upgradePossibleStatus {
+		statusDetail[status] = 0.0
+	}
+	statusDetail[nonZeroStatus] = 1.0
+	return statusDetail
+}
+
+func TestUpgradeCollector_GenerateUpgradeComponentMetrics(t *testing.T) {
+	upgradeStatus := upgrade.UpgradeStatus{
+		OverallUpgradeStatus: "IN_PROGRESS",
+		ComponentStatus: []upgrade.ComponentUpgradeStatus{
+			{
+				ComponentType:   "EDGE",
+				Status:          "SUCCESS",
+				PercentComplete: 100,
+			}, {
+				ComponentType:   "HOST",
+				Status:          "in_progress",
+				PercentComplete: 42.5,
+			}, {
+				ComponentType: "MP",
+				Status:        "NOT_STARTED",
+			},
+		},
+	}
+	componentTargetVersions := []upgrade.ComponentTargetVersion{
+		{ComponentType: "EDGE", TargetVersion: fakeUpgradeTargetVersion},
+		{ComponentType: "HOST", TargetVersion: fakeUpgradeTargetVersion},
+		{ComponentType: "MP", TargetVersion: fakeUpgradeTargetVersion},
+	}
+	upgradeUnitGroupStatusesResponses := []upgradeUnitGroupStatusesResponse{
+		{
+			ComponentType: "EDGE",
+			UpgradeUnitGroupStatuses: []upgrade.UpgradeUnitGroupStatus{
+				{GroupId: "fake-group-id-01", UpgradeUnitCount: 2},
+			},
+		}, {
+			ComponentType: "HOST",
+			UpgradeUnitGroupStatuses: []upgrade.UpgradeUnitGroupStatus{
+				{GroupId: "fake-group-id-02", UpgradeUnitCount: 8, FailedCount: 1},
+				{GroupId: "fake-group-id-03", UpgradeUnitCount: 8, FailedCount: 2},
+			},
+		}, {
+			ComponentType: "MP",
+			Error:         errors.New("error listing upgrade unit group status"),
+		},
+	}
+	expectedMetrics := []upgradeComponentMetric{
+		{
+			ComponentType:   "EDGE",
+			TargetVersion:   fakeUpgradeTargetVersion,
+			StatusDetail:    buildExpectedUpgradeStatusDetail("SUCCESS"),
+			PercentComplete: 100,
+			FailedUnits:     0,
+		}, {
+			ComponentType:   "HOST",
+			TargetVersion:   fakeUpgradeTargetVersion,
+			StatusDetail:    buildExpectedUpgradeStatusDetail("IN_PROGRESS"),
+			PercentComplete: 42.5,
+			FailedUnits:     3,
+		}, {
+			ComponentType:   "MP",
+			TargetVersion:   fakeUpgradeTargetVersion,
+			StatusDetail:    buildExpectedUpgradeStatusDetail("NOT_STARTED"),
+			PercentComplete: 0,
+			FailedUnits:     0,
+		},
+	}
+	mockClient := &mockUpgradeClient{
+		upgradeUnitGroupStatusesResponses: upgradeUnitGroupStatusesResponses,
+	}
+	logger := log.NewNopLogger()
+	collector := newUpgradeCollector(mockClient, logger)
+	metrics := collector.generateUpgradeComponentMetrics(upgradeStatus, componentTargetVersions)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}
+
+func TestUpgradeCollector_GenerateUpgradeUnitMetrics(t *testing.T) {
+	upgradeUnitsStats := []upgrade.UpgradeUnitTypeStats{
+		{
+			Type_:               "HOST",
+			Version:             fakeUpgradeCurrentVersion,
+			NodeCount:           6,
+			NodeWithIssuesCount: 1,
+		}, {
+			Type_:     "HOST",
+			Version:   fakeUpgradeTargetVersion,
+			NodeCount: 10,
+		},
+	}
+	expectedMetrics := []upgradeUnitMetric{
+		{
+			ComponentType:   "HOST",
+			Version:         fakeUpgradeCurrentVersion,
+			Units:           6,
+			UnitsWithIssues: 1,
+		}, {
+			ComponentType:   "HOST",
+			Version:         fakeUpgradeTargetVersion,
+			Units:           10,
+			UnitsWithIssues: 0,
+		},
+	}
+	mockClient := &mockUpgradeClient{}
+	logger := log.NewNopLogger()
+	collector := newUpgradeCollector(mockClient, logger)
+	metrics := collector.generateUpgradeUnitMetrics(upgradeUnitsStats)
+	assert.ElementsMatch(t, expectedMetrics, metrics)
+}